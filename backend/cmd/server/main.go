@@ -3,38 +3,95 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nikhilgoenkatech/kafka-ui/internal/api"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/constants"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
 	"github.com/nikhilgoenkatech/kafka-ui/pkg/utils"
 )
 
 func main() {
+	defaultConfigPath := os.Getenv("KAFKA_UI_CONFIG")
+	if defaultConfigPath == "" {
+		defaultConfigPath = "config.yml"
+	}
+	defaultStorePath := os.Getenv("KAFKA_UI_CLUSTER_STORE")
+	if defaultStorePath == "" {
+		defaultStorePath = "clusters.json"
+	}
+	configPath := flag.String("config", defaultConfigPath, "path to the configuration file (env KAFKA_UI_CONFIG)")
+	storePath := flag.String("cluster-store", defaultStorePath, "path to the file storing clusters added at runtime (env KAFKA_UI_CLUSTER_STORE)")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("failed to load config %s: %v", *configPath, err)
+	}
+	if cfg.Auth.JWTSecret == constants.SecretKeyDev {
+		log.Printf("WARNING: auth.jwtSecret is not set, using the insecure development secret")
+	}
+
+	// Connect to the clusters defined in the config file, then replay the
+	// clusters that were added through the API before the last restart.
+	kafkaSvc := kafka.NewService(kafka.NewFileClusterStore(*storePath))
+	defer kafkaSvc.Close()
+	bootstrapClusters(kafkaSvc, cfg.Clusters)
+
+	restored, errs := kafkaSvc.RestoreClusters()
+	for _, err := range errs {
+		log.Printf("Cluster store: %v", err)
+	}
+	log.Printf("Restored %d clusters from %s", restored, *storePath)
+
+	// Clusters that failed to connect above are retried by the health monitor.
+	kafkaSvc.StartHealthMonitor(30 * time.Second)
+
+	// Apply cluster changes in the config file without a restart.
+	stopWatch := make(chan struct{})
+	defer close(stopWatch)
+	go watchConfig(*configPath, cfg, kafkaSvc, stopWatch)
+
 	// Setup Gin router with custom logging
 	router := gin.New()
 	router.Use(utils.LoggingMiddleware())
-	router.Use(utils.CORSMiddleware())
-	router.Use(utils.RateLimitMiddleware(100)) // 100 requests per minute per IP
+	router.Use(utils.CORSMiddleware(cfg.Server.CORSOrigins))
+	if cfg.Server.RateLimit > 0 {
+		router.Use(utils.RateLimitMiddleware(cfg.Server.RateLimit)) // requests per minute per IP
+	}
 
-	// Register all routes (including authentication)
-	api.RegisterRoutes(router)
+	// Register all routes (including authentication) under the base path
+	api.RegisterRoutes(router.Group(cfg.Server.BasePath), kafkaSvc, cfg)
 
 	// Server setup
 	srv := &http.Server{
-		Addr:    ":8080",
-		Handler: router,
+		Addr:         cfg.Server.Addr(),
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
 	// Graceful shutdown
 	go func() {
-		log.Printf("Server starting on port 8080...")
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if cfg.Server.TLS.Enabled() {
+			log.Printf("Server starting on https://%s%s/ ...", srv.Addr, cfg.Server.BasePath)
+			err = srv.ListenAndServeTLS(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		} else {
+			log.Printf("Server starting on http://%s%s/ ...", srv.Addr, cfg.Server.BasePath)
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("listen: %s\n", err)
 		}
 	}()
@@ -44,7 +101,7 @@ func main() {
 	<-quit
 	log.Println("Shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
@@ -52,3 +109,72 @@ func main() {
 
 	log.Println("Server exiting")
 }
+
+// bootstrapClusters registers every configured cluster. A cluster that fails
+// to connect is reported and left for the health monitor to retry, so the
+// server can still start.
+func bootstrapClusters(kafkaSvc *kafka.Service, clusters []config.ClusterConfig) {
+	connected := 0
+	for _, cluster := range clusters {
+		if err := kafkaSvc.AddStaticCluster(cluster); err != nil {
+			log.Printf("Cluster %s: %v", cluster.Name, err)
+			continue
+		}
+		connected++
+		log.Printf("Cluster %s connected (%v)", cluster.Name, cluster.Brokers)
+	}
+	log.Printf("Connected to %d of %d configured clusters", connected, len(clusters))
+}
+
+// serverAndAuthChanged reports whether the server or auth settings of two
+// configs differ.
+func serverAndAuthChanged(a, b *config.Config) bool {
+	return !reflect.DeepEqual(a.Server, b.Server) || a.Auth != b.Auth
+}
+
+// configPollInterval is how often the config file is checked for changes.
+const configPollInterval = 5 * time.Second
+
+// watchConfig reloads the config file when it changes or the process receives
+// SIGHUP, and reconciles the configured clusters with it. A config that fails
+// to load is reported and the current clusters are kept.
+func watchConfig(path string, current *config.Config, kafkaSvc *kafka.Service, stop <-chan struct{}) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	changed := config.WatchFile(path, configPollInterval, stop)
+	last := current
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-hup:
+			log.Printf("Received SIGHUP, reloading %s", path)
+		case <-changed:
+			log.Printf("Config file %s changed, reloading", path)
+		}
+
+		cfg, err := config.LoadConfig(path)
+		if err != nil {
+			log.Printf("Config reload failed, keeping the current configuration: %v", err)
+			continue
+		}
+
+		summary := kafkaSvc.SyncStaticClusters(cfg.Clusters)
+		log.Printf("Config reloaded: %s", summary)
+		for _, err := range summary.Errors {
+			log.Printf("Config reload: %v", err)
+		}
+		// Server and auth settings keep their startup values. Report a
+		// change once, when the file stops or starts matching what runs.
+		if serverAndAuthChanged(cfg, last) {
+			if serverAndAuthChanged(cfg, current) {
+				log.Printf("Config reload: server and auth settings differ from those in effect since startup; restart to apply them")
+			} else {
+				log.Printf("Config reload: server and auth settings match those in effect since startup again")
+			}
+		}
+		last = cfg
+	}
+}
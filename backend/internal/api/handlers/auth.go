@@ -18,6 +18,11 @@ var jwtConfig = utils.JWTConfig{
 	Duration:  24 * time.Hour,
 }
 
+// SetJWTSecret sets the key used to sign JWTs.
+func SetJWTSecret(secret string) {
+	jwtConfig.SecretKey = secret
+}
+
 // Demo admin user hash (password: "admin123")
 var demoAdminHash = constants.DemoAdminHash
 
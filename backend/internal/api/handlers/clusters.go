@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
 	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
 	"github.com/nikhilgoenkatech/kafka-ui/pkg/errors"
 	"github.com/nikhilgoenkatech/kafka-ui/pkg/utils"
@@ -20,17 +21,78 @@ func (h *ClusterHandler) ListClusters(c *gin.Context) {
 	utils.SendSuccess(c, clusters, "Clusters retrieved successfully")
 }
 
-func (h *ClusterHandler) AddCluster(c *gin.Context) {
-	var req struct {
-		Name    string   `json:"name" binding:"required"`
-		Brokers []string `json:"brokers" binding:"required"`
+// GetCluster handles GET requests to /api/clusters/:clusterName
+func (h *ClusterHandler) GetCluster(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+	cluster, err := h.kafkaSvc.GetCluster(clusterName)
+	if err != nil {
+		utils.SendError(c, errors.NewNotFoundError("Cluster "+clusterName))
+		return
+	}
+
+	utils.SendSuccess(c, cluster, "Cluster retrieved successfully")
+}
+
+// ClusterRequest is the payload for adding or updating a cluster.
+type ClusterRequest struct {
+	Name     string              `json:"name"`
+	Brokers  []string            `json:"brokers" binding:"required"`
+	Version  string              `json:"version"`
+	Tags     []string            `json:"tags"`
+	ReadOnly bool                `json:"readOnly"`
+	TLS      ClusterTLSRequest   `json:"tls"`
+	SASL     config.SASLConfig   `json:"sasl"`
+	Tuning   config.TuningConfig `json:"tuning"`
+}
+
+// ClusterTLSRequest is the TLS part of a cluster payload. Certificates and
+// keys are only accepted inline as PEM; the file path settings are reserved
+// for the configuration file so API users cannot make the server read local
+// files.
+type ClusterTLSRequest struct {
+	Enabled            bool   `json:"enabled"`
+	CACert             string `json:"caCert"`
+	ClientCert         string `json:"clientCert"`
+	ClientKey          string `json:"clientKey"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+func (r ClusterRequest) toConfig() config.ClusterConfig {
+	return config.ClusterConfig{
+		Name:     r.Name,
+		Brokers:  r.Brokers,
+		Version:  r.Version,
+		Tags:     r.Tags,
+		ReadOnly: r.ReadOnly,
+		TLS: config.TLSConfig{
+			Enabled:            r.TLS.Enabled,
+			CACert:             r.TLS.CACert,
+			ClientCert:         r.TLS.ClientCert,
+			ClientKey:          r.TLS.ClientKey,
+			InsecureSkipVerify: r.TLS.InsecureSkipVerify,
+		},
+		SASL:   r.SASL,
+		Tuning: r.Tuning,
 	}
+}
+
+func (h *ClusterHandler) AddCluster(c *gin.Context) {
+	var req ClusterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.SendError(c, errors.NewValidationError("Invalid request: "+err.Error()))
 		return
 	}
+	if req.Name == "" {
+		utils.SendError(c, errors.NewValidationError("Cluster name is required"))
+		return
+	}
+	cluster := req.toConfig()
+	if err := cluster.Validate(); err != nil {
+		utils.SendError(c, errors.NewValidationError("Invalid cluster settings: "+err.Error()))
+		return
+	}
 
-	if err := h.kafkaSvc.AddCluster(req.Name, req.Brokers); err != nil {
+	if err := h.kafkaSvc.AddCluster(cluster); err != nil {
 		utils.SendError(c, errors.NewInternalError("Failed to add cluster: "+err.Error()))
 		return
 	}
@@ -38,8 +100,84 @@ func (h *ClusterHandler) AddCluster(c *gin.Context) {
 	utils.SendSuccess(c, gin.H{"name": req.Name}, "Cluster added successfully")
 }
 
+// TestCluster handles POST requests to /api/clusters/test. It runs the
+// connection logic of AddCluster without registering the cluster and
+// returns a step-by-step diagnostic.
+func (h *ClusterHandler) TestCluster(c *gin.Context) {
+	var req ClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, errors.NewValidationError("Invalid request: "+err.Error()))
+		return
+	}
+	if req.Name == "" {
+		req.Name = "connection-test"
+	}
+	cluster := req.toConfig()
+	if err := cluster.Validate(); err != nil {
+		utils.SendError(c, errors.NewValidationError("Invalid cluster settings: "+err.Error()))
+		return
+	}
+
+	result := h.kafkaSvc.TestConnection(c.Request.Context(), cluster)
+	message := "Connection test succeeded"
+	if !result.Success {
+		message = "Connection test failed"
+	}
+	utils.SendSuccess(c, result, message)
+}
+
+// UpdateCluster handles PUT requests to /api/clusters/:clusterName. The new
+// settings are tried on a fresh connection and only swapped in on success.
+// Secrets left empty in the request keep their current value. The read-only
+// flag of a cluster cannot be cleared, since any user could otherwise lift it.
+func (h *ClusterHandler) UpdateCluster(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+
+	var req ClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, errors.NewValidationError("Invalid request: "+err.Error()))
+		return
+	}
+	if req.Name != "" && req.Name != clusterName {
+		utils.SendError(c, errors.NewValidationError("Cluster name cannot be changed"))
+		return
+	}
+	req.Name = clusterName
+
+	if !h.kafkaSvc.HasCluster(clusterName) {
+		utils.SendError(c, errors.NewNotFoundError("Cluster "+clusterName))
+		return
+	}
+	if h.kafkaSvc.IsStatic(clusterName) {
+		utils.SendError(c, errors.NewForbiddenError("Cluster "+clusterName+" is defined in the configuration file and cannot be edited"))
+		return
+	}
+	if h.kafkaSvc.IsReadOnly(clusterName) && !req.ReadOnly {
+		utils.SendError(c, errors.NewForbiddenError("Cluster "+clusterName+" is read-only and the flag cannot be cleared through the API"))
+		return
+	}
+
+	// The settings are validated by the service once the secrets left
+	// empty have been filled in from the current definition.
+	if err := h.kafkaSvc.UpdateCluster(req.toConfig()); err != nil {
+		utils.SendError(c, errors.NewValidationError("Failed to update cluster: "+err.Error()))
+		return
+	}
+
+	utils.SendSuccess(c, gin.H{"name": clusterName}, "Cluster updated successfully")
+}
+
 func (h *ClusterHandler) RemoveCluster(c *gin.Context) {
 	clusterName := c.Param("clusterName")
+	if h.kafkaSvc.IsStatic(clusterName) {
+		utils.SendError(c, errors.NewForbiddenError("Cluster "+clusterName+" is defined in the configuration file and cannot be removed"))
+		return
+	}
+	if h.kafkaSvc.IsReadOnly(clusterName) {
+		utils.SendError(c, errors.NewForbiddenError("Cluster "+clusterName+" is read-only and cannot be removed through the API"))
+		return
+	}
+
 	if err := h.kafkaSvc.RemoveCluster(clusterName); err != nil {
 		utils.SendError(c, errors.NewInternalError("Failed to remove cluster: "+err.Error()))
 		return
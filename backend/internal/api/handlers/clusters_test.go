@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
+)
+
+// testClientCertificate returns a self-signed certificate and its private
+// key as PEM.
+func testClientCertificate(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kafka-ui"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestUpdateClusterAcceptsRedactedDefinition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	certPEM, keyPEM := testClientCertificate(t)
+
+	// A runtime mTLS cluster whose broker refuses connections, so the
+	// update gets past validation and stops at the trial connection.
+	store := kafka.NewFileClusterStore(filepath.Join(t.TempDir(), "clusters.json"))
+	if err := store.Save(config.ClusterConfig{
+		Name:    "mtls",
+		Brokers: []string{"127.0.0.1:1"},
+		Version: "3.5.0",
+		TLS:     config.TLSConfig{Enabled: true, ClientCert: certPEM, ClientKey: keyPEM},
+		Tuning:  config.TuningConfig{DialTimeout: config.Duration(100 * time.Millisecond)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	svc := kafka.NewService(store)
+	svc.RestoreClusters()
+
+	handler := NewClusterHandler(svc)
+	router := gin.New()
+	router.GET("/clusters/:clusterName", handler.GetCluster)
+	router.PUT("/clusters/:clusterName", handler.UpdateCluster)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/clusters/mtls", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d: %s", rec.Code, rec.Body)
+	}
+	var got struct {
+		Data struct {
+			Config json.RawMessage `json:"config"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(got.Data.Config), "PRIVATE KEY") {
+		t.Fatal("GET returned the client key")
+	}
+	if !strings.Contains(string(got.Data.Config), "CERTIFICATE") {
+		t.Fatal("GET did not return the client certificate")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/clusters/mtls", bytes.NewReader(got.Data.Config)))
+	body := rec.Body.String()
+	if strings.Contains(body, "invalid cluster settings") || strings.Contains(body, "client key") {
+		t.Fatalf("PUT of the redacted definition was rejected: %s", body)
+	}
+	if !strings.Contains(body, "failed to connect to cluster mtls") {
+		t.Fatalf("PUT did not reach the trial connection: %s", body)
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/constants"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
+	"github.com/nikhilgoenkatech/kafka-ui/pkg/errors"
+	"github.com/nikhilgoenkatech/kafka-ui/pkg/utils"
+)
+
+type LeaderElectionHandler struct {
+	service *kafka.LeaderElectionService
+}
+
+func NewLeaderElectionHandler(service *kafka.LeaderElectionService) *LeaderElectionHandler {
+	return &LeaderElectionHandler{service: service}
+}
+
+// ElectLeaders handles POST requests to /api/clusters/:clusterName/leader-elections
+func (h *LeaderElectionHandler) ElectLeaders(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+
+	var request kafka.LeaderElectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+
+	result, err := h.service.Elect(c.Request.Context(), clusterName, request)
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToElectLeaders+err.Error()))
+		return
+	}
+	utils.SendSuccess(c, result, fmt.Sprintf(constants.MsgLeaderElectionFmt, result.Type, len(result.Partitions)))
+}
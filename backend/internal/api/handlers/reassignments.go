@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/constants"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
+	"github.com/nikhilgoenkatech/kafka-ui/pkg/errors"
+	"github.com/nikhilgoenkatech/kafka-ui/pkg/utils"
+)
+
+type ReassignmentHandler struct {
+	service *kafka.ReassignmentService
+}
+
+func NewReassignmentHandler(service *kafka.ReassignmentService) *ReassignmentHandler {
+	return &ReassignmentHandler{service: service}
+}
+
+// CancelReassignmentRequest lists the partitions whose reassignment is
+// cancelled. Every in-progress reassignment is only cancelled with All.
+type CancelReassignmentRequest struct {
+	Partitions []kafka.TopicPartition `json:"partitions"`
+	All        bool                   `json:"all"`
+}
+
+// GetReassignments handles GET requests to /api/clusters/:clusterName/reassignments
+func (h *ReassignmentHandler) GetReassignments(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+
+	reassignments, err := h.service.List(c.Request.Context(), clusterName, c.Query("topic"))
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToGetReassignments+err.Error()))
+		return
+	}
+	utils.SendSuccess(c, reassignments, constants.MsgReassignmentsRetrieved)
+}
+
+// SubmitReassignment handles POST requests to /api/clusters/:clusterName/reassignments
+func (h *ReassignmentHandler) SubmitReassignment(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+
+	var request kafka.ReassignmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+
+	result, err := h.service.Submit(c.Request.Context(), clusterName, request)
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToSubmitReassignment+err.Error()))
+		return
+	}
+	utils.SendSuccess(c, result, fmt.Sprintf(constants.MsgReassignmentSubmittedFmt, len(result.Partitions)))
+}
+
+// CancelReassignments handles POST requests to /api/clusters/:clusterName/reassignments/cancel
+func (h *ReassignmentHandler) CancelReassignments(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+
+	var request CancelReassignmentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+
+	cancelled, err := h.service.Cancel(c.Request.Context(), clusterName, request.Partitions, request.All)
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToCancelReassignments+err.Error()))
+		return
+	}
+	utils.SendSuccess(c, cancelled, fmt.Sprintf(constants.MsgReassignmentsCancelledFmt, len(cancelled)))
+}
+
+// RemoveThrottle handles DELETE requests to /api/clusters/:clusterName/reassignments/throttle
+func (h *ReassignmentHandler) RemoveThrottle(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+
+	if err := h.service.RemoveThrottle(c.Request.Context(), clusterName, c.QueryArray("topic")); err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToRemoveThrottle+err.Error()))
+		return
+	}
+	utils.SendSuccess(c, gin.H{"topics": c.QueryArray("topic")}, constants.MsgReplicationThrottleRemoved)
+}
+
+// ChangeReplicationFactor handles POST requests to /api/clusters/:clusterName/topics/:topicName/replication-factor
+func (h *ReassignmentHandler) ChangeReplicationFactor(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+	topicName := c.Param("topicName")
+
+	var request kafka.ReplicationFactorChange
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+
+	plan, err := h.service.ChangeReplicationFactor(c.Request.Context(), clusterName, topicName, request)
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToChangeReplicationFactor+err.Error()))
+		return
+	}
+
+	message := fmt.Sprintf(constants.MsgReplicationFactorChangingFmt, topicName, plan.ReplicationFactor)
+	if request.DryRun {
+		message = fmt.Sprintf(constants.MsgReplicationFactorPlannedFmt, topicName, plan.ReplicationFactor)
+	}
+	utils.SendSuccess(c, plan, message)
+}
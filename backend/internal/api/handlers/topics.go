@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	stderrors "errors"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
@@ -20,10 +21,42 @@ func NewTopicHandler(service *kafka.TopicService) *TopicHandler {
 	}
 }
 
+// TopicListRequest holds the query parameters of the topic list.
+type TopicListRequest struct {
+	Search       string `form:"search"`
+	Regex        bool   `form:"regex"`
+	Sort         string `form:"sort"`
+	Order        string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Page         int    `form:"page"`
+	PageSize     int    `form:"pageSize"`
+	Cursor       string `form:"cursor"`
+	HideInternal bool   `form:"hideInternal"`
+}
+
 // GetTopics handles GET requests to /api/clusters/:clusterName/topics
 func (h *TopicHandler) GetTopics(c *gin.Context) {
 	clusterName := c.Param("clusterName")
-	topics, err := h.service.GetTopics(c.Request.Context(), clusterName)
+
+	var request TopicListRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+
+	topics, err := h.service.GetTopics(c.Request.Context(), clusterName, kafka.TopicListQuery{
+		Search:       request.Search,
+		Regex:        request.Regex,
+		SortBy:       request.Sort,
+		Descending:   request.Order == "desc",
+		Page:         request.Page,
+		PageSize:     request.PageSize,
+		Cursor:       request.Cursor,
+		HideInternal: request.HideInternal,
+	})
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
 	if err != nil {
 		utils.SendError(c, errors.NewInternalError(constants.MsgFailedToGetTopics+err.Error()))
 		return
@@ -51,9 +84,13 @@ func (h *TopicHandler) CreateTopic(c *gin.Context) {
 	clusterName := c.Param("clusterName")
 
 	var request struct {
-		Name       string `json:"name" binding:"required"`
-		Partitions int    `json:"partitions" binding:"required"`
-		Replicas   int    `json:"replicas" binding:"required"`
+		Name       string            `json:"name" binding:"required"`
+		Partitions int               `json:"partitions"`
+		Replicas   int               `json:"replicas"`
+		Configs    map[string]string `json:"configs"`
+		// ReplicaAssignment lists the replica broker IDs per partition.
+		ReplicaAssignment [][]int32 `json:"replicaAssignment"`
+		ValidateOnly      bool      `json:"validateOnly"`
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -67,6 +104,16 @@ func (h *TopicHandler) CreateTopic(c *gin.Context) {
 		return
 	}
 
+	// An explicit assignment defines the partition count and replication factor
+	if len(request.ReplicaAssignment) > 0 {
+		if err := validateReplicaAssignment(request.ReplicaAssignment, request.Partitions, request.Replicas); err != nil {
+			utils.SendError(c, errors.NewValidationError(constants.MsgInvalidReplicaAssignment+err.Error()))
+			return
+		}
+		request.Partitions = len(request.ReplicaAssignment)
+		request.Replicas = len(request.ReplicaAssignment[0])
+	}
+
 	// Validate partitions and replicas
 	if request.Partitions <= 0 {
 		utils.SendError(c, errors.NewValidationError(constants.MsgPartitionsGreaterThanZero))
@@ -77,15 +124,56 @@ func (h *TopicHandler) CreateTopic(c *gin.Context) {
 		return
 	}
 
-	err := h.service.CreateTopic(c.Request.Context(), clusterName, request.Name, int32(request.Partitions), int16(request.Replicas))
+	for name, value := range request.Configs {
+		if err := kafka.ValidateTopicConfig(name, value); err != nil {
+			utils.SendError(c, errors.NewValidationError(constants.MsgInvalidTopicConfig+err.Error()))
+			return
+		}
+	}
+
+	opts := kafka.CreateTopicOptions{
+		Configs:           request.Configs,
+		ReplicaAssignment: request.ReplicaAssignment,
+		ValidateOnly:      request.ValidateOnly,
+	}
+	err := h.service.CreateTopic(c.Request.Context(), clusterName, request.Name, int32(request.Partitions), int16(request.Replicas), opts)
 	if err != nil {
 		utils.SendError(c, errors.NewInternalError(constants.MsgFailedToCreateTopic+err.Error()))
 		return
 	}
 
+	if request.ValidateOnly {
+		utils.SendSuccess(c, gin.H{"name": request.Name, "validateOnly": true}, fmt.Sprintf(constants.MsgTopicCreateValidatedFmt, request.Name))
+		return
+	}
 	utils.SendSuccess(c, gin.H{"name": request.Name}, fmt.Sprintf(constants.MsgTopicCreatedSuccessfullyFmt, request.Name))
 }
 
+// validateReplicaAssignment checks that every partition has the same number
+// of distinct replicas, matching partitions and replicas when they are given.
+func validateReplicaAssignment(assignment [][]int32, partitions, replicas int) error {
+	if partitions > 0 && partitions != len(assignment) {
+		return fmt.Errorf("%d partitions requested but %d assigned", partitions, len(assignment))
+	}
+	want := len(assignment[0])
+	if replicas > 0 {
+		want = replicas
+	}
+	for partition, brokers := range assignment {
+		if len(brokers) == 0 || len(brokers) != want {
+			return fmt.Errorf("partition %d has %d replicas, expected %d", partition, len(brokers), want)
+		}
+		seen := make(map[int32]bool, len(brokers))
+		for _, broker := range brokers {
+			if seen[broker] {
+				return fmt.Errorf("partition %d lists broker %d more than once", partition, broker)
+			}
+			seen[broker] = true
+		}
+	}
+	return nil
+}
+
 // DeleteTopic handles DELETE requests to /api/clusters/:clusterName/topics/:topicName
 func (h *TopicHandler) DeleteTopic(c *gin.Context) {
 	clusterName := c.Param("clusterName")
@@ -105,3 +193,93 @@ func (h *TopicHandler) DeleteTopic(c *gin.Context) {
 
 	utils.SendSuccess(c, gin.H{"name": topicName}, fmt.Sprintf(constants.MsgTopicDeletedSuccessfullyFmt, topicName))
 }
+
+// UpdateTopicConfigs handles PATCH requests to /api/clusters/:clusterName/topics/:topicName/configs
+func (h *TopicHandler) UpdateTopicConfigs(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+	topicName := c.Param("topicName")
+
+	var update kafka.TopicConfigUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+	if err := update.Validate(); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidTopicConfig+err.Error()))
+		return
+	}
+
+	result, err := h.service.UpdateTopicConfigs(c.Request.Context(), clusterName, topicName, update)
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToUpdateTopicConfigs+err.Error()))
+		return
+	}
+
+	message := fmt.Sprintf(constants.MsgTopicConfigsUpdatedFmt, topicName)
+	if update.ValidateOnly {
+		message = fmt.Sprintf(constants.MsgTopicConfigsValidatedFmt, topicName)
+	}
+	utils.SendSuccess(c, result, message)
+}
+
+// IncreasePartitions handles POST requests to /api/clusters/:clusterName/topics/:topicName/partitions
+func (h *TopicHandler) IncreasePartitions(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+	topicName := c.Param("topicName")
+
+	var request kafka.PartitionIncrease
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+	if len(request.Assignment) > 0 {
+		if err := validateReplicaAssignment(request.Assignment, 0, 0); err != nil {
+			utils.SendError(c, errors.NewValidationError(constants.MsgInvalidReplicaAssignment+err.Error()))
+			return
+		}
+	}
+
+	result, err := h.service.IncreasePartitions(c.Request.Context(), clusterName, topicName, request)
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToIncreasePartitions+err.Error()))
+		return
+	}
+
+	message := fmt.Sprintf(constants.MsgPartitionsIncreasedFmt, topicName, result.Count)
+	if request.ValidateOnly {
+		message = fmt.Sprintf(constants.MsgPartitionIncreaseValidatedFmt, topicName, result.Count)
+	}
+	utils.SendSuccess(c, result, message)
+}
+
+// DeleteRecords handles POST requests to /api/clusters/:clusterName/topics/:topicName/purge
+func (h *TopicHandler) DeleteRecords(c *gin.Context) {
+	clusterName := c.Param("clusterName")
+	topicName := c.Param("topicName")
+
+	var request kafka.RecordDeletion
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.SendError(c, errors.NewValidationError(constants.MsgInvalidRequest+err.Error()))
+		return
+	}
+
+	result, err := h.service.DeleteRecords(c.Request.Context(), clusterName, topicName, request)
+	if stderrors.Is(err, kafka.ErrInvalidRequest) {
+		utils.SendError(c, errors.NewValidationError(err.Error()))
+		return
+	}
+	if err != nil {
+		utils.SendError(c, errors.NewKafkaError(constants.MsgFailedToDeleteRecords+err.Error()))
+		return
+	}
+
+	message := fmt.Sprintf(constants.MsgRecordsDeletedFmt, result.Removed, topicName)
+	if request.DryRun {
+		message = fmt.Sprintf(constants.MsgRecordDeletionDryRunFmt, result.Removed, topicName)
+	}
+	utils.SendSuccess(c, result, message)
+}
@@ -15,6 +15,11 @@ var jwtConfig = utils.JWTConfig{
 	Duration:  24 * time.Hour,
 }
 
+// SetJWTSecret sets the key used to validate JWTs.
+func SetJWTSecret(secret string) {
+	jwtConfig.SecretKey = secret
+}
+
 // AuthMiddleware validates JWT tokens
 func AuthMiddleware() gin.HandlerFunc {
 	return utils.AuthMiddleware(jwtConfig)
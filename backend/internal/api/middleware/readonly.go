@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
+	"github.com/nikhilgoenkatech/kafka-ui/pkg/errors"
+	"github.com/nikhilgoenkatech/kafka-ui/pkg/utils"
+)
+
+// ReadOnlyMiddleware rejects mutating requests against clusters marked
+// read-only. It must be installed on routes with a :clusterName parameter;
+// GET, HEAD and OPTIONS requests always pass.
+func ReadOnlyMiddleware(kafkaSvc *kafka.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		clusterName := c.Param("clusterName")
+		if kafkaSvc.IsReadOnly(clusterName) {
+			utils.SendError(c, errors.NewForbiddenError("Cluster "+clusterName+" is read-only"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/nikhilgoenkatech/kafka-ui/internal/api/handlers"
 	"github.com/nikhilgoenkatech/kafka-ui/internal/api/middleware"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
 	"github.com/nikhilgoenkatech/kafka-ui/internal/kafka"
 )
 
-func RegisterRoutes(router *gin.Engine) {
-	// Initialize Kafka service and handlers
-	kafkaSvc := kafka.NewService()
+// RegisterRoutes registers the API routes on router, which is the root
+// group or the group of the configured base path.
+func RegisterRoutes(router *gin.RouterGroup, kafkaSvc *kafka.Service, cfg *config.Config) {
+	handlers.SetJWTSecret(cfg.Auth.JWTSecret)
+	middleware.SetJWTSecret(cfg.Auth.JWTSecret)
 
 	// Initialize services
 	topicSvc := kafka.NewTopicService(kafkaSvc)
@@ -17,6 +20,8 @@ func RegisterRoutes(router *gin.Engine) {
 	cgSvc := kafka.NewConsumerGroupService(kafkaSvc)
 	msgSvc := kafka.NewMessageService(kafkaSvc)
 	metricsSvc := kafka.NewMetricsService(kafkaSvc)
+	reassignmentSvc := kafka.NewReassignmentService(kafkaSvc)
+	electionSvc := kafka.NewLeaderElectionService(kafkaSvc)
 
 	// Initialize handlers
 	clusterHandler := handlers.NewClusterHandler(kafkaSvc)
@@ -25,6 +30,8 @@ func RegisterRoutes(router *gin.Engine) {
 	cgHandler := handlers.NewConsumerGroupHandler(cgSvc)
 	msgHandler := handlers.NewMessageHandler(msgSvc)
 	metricsHandler := handlers.NewMetricsHandler(metricsSvc)
+	reassignmentHandler := handlers.NewReassignmentHandler(reassignmentSvc)
+	electionHandler := handlers.NewLeaderElectionHandler(electionSvc)
 
 	// Public routes (no authentication required)
 	api := router.Group("/api")
@@ -42,31 +49,49 @@ func RegisterRoutes(router *gin.Engine) {
 		protected.GET("/auth/profile", handlers.GetProfile)
 		protected.PUT("/auth/change-password", handlers.ChangePassword)
 
-
-		
 		// Kafka management routes
 		protected.GET("/clusters", clusterHandler.ListClusters)
 		protected.POST("/clusters", clusterHandler.AddCluster)
+		protected.POST("/clusters/test", clusterHandler.TestCluster)
+		protected.GET("/clusters/:clusterName", clusterHandler.GetCluster)
+		protected.PUT("/clusters/:clusterName", clusterHandler.UpdateCluster)
 		protected.DELETE("/clusters/:clusterName", clusterHandler.RemoveCluster)
 
-		protected.GET("/clusters/:clusterName/topics", topicHandler.GetTopics)
-		protected.POST("/clusters/:clusterName/topics", topicHandler.CreateTopic)
-		protected.GET("/clusters/:clusterName/topics/:topicName", topicHandler.GetTopicDetails)
-		protected.DELETE("/clusters/:clusterName/topics/:topicName", topicHandler.DeleteTopic)
+		// Cluster scoped routes. Mutating requests are rejected for
+		// read-only clusters.
+		cluster := protected.Group("/clusters/:clusterName")
+		cluster.Use(middleware.ReadOnlyMiddleware(kafkaSvc))
+		{
+			cluster.GET("/topics", topicHandler.GetTopics)
+			cluster.POST("/topics", topicHandler.CreateTopic)
+			cluster.GET("/topics/:topicName", topicHandler.GetTopicDetails)
+			cluster.DELETE("/topics/:topicName", topicHandler.DeleteTopic)
+			cluster.PATCH("/topics/:topicName/configs", topicHandler.UpdateTopicConfigs)
+			cluster.POST("/topics/:topicName/partitions", topicHandler.IncreasePartitions)
+			cluster.POST("/topics/:topicName/purge", topicHandler.DeleteRecords)
+			cluster.POST("/topics/:topicName/replication-factor", reassignmentHandler.ChangeReplicationFactor)
+
+			cluster.GET("/brokers", brokerHandler.GetBrokers)
+
+			cluster.GET("/reassignments", reassignmentHandler.GetReassignments)
+			cluster.POST("/reassignments", reassignmentHandler.SubmitReassignment)
+			cluster.POST("/reassignments/cancel", reassignmentHandler.CancelReassignments)
+			cluster.DELETE("/reassignments/throttle", reassignmentHandler.RemoveThrottle)
 
-		protected.GET("/clusters/:clusterName/brokers", brokerHandler.GetBrokers)
+			cluster.POST("/leader-elections", electionHandler.ElectLeaders)
 
-		protected.GET("/clusters/:clusterName/consumer-groups", cgHandler.GetConsumerGroups)
-		protected.GET("/clusters/:clusterName/consumer-groups/:groupId", cgHandler.GetConsumerGroupDetails)
+			cluster.GET("/consumer-groups", cgHandler.GetConsumerGroups)
+			cluster.GET("/consumer-groups/:groupId", cgHandler.GetConsumerGroupDetails)
 
-		protected.GET("/clusters/:clusterName/topics/:topicName/messages", msgHandler.GetMessages)
-		protected.POST("/clusters/:clusterName/topics/:topicName/messages", msgHandler.ProduceMessage)
+			cluster.GET("/topics/:topicName/messages", msgHandler.GetMessages)
+			cluster.POST("/topics/:topicName/messages", msgHandler.ProduceMessage)
 
-		// Metrics routes
-		protected.GET("/clusters/:clusterName/metrics/consumer-lag", metricsHandler.GetConsumerGroupsLag)
-		protected.GET("/clusters/:clusterName/metrics/cluster-health", metricsHandler.GetClusterHealth)
-		protected.GET("/clusters/:clusterName/metrics/brokers", metricsHandler.GetBrokerMetrics)
-		protected.GET("/clusters/:clusterName/metrics/topics", metricsHandler.GetTopicMetrics)
-		protected.GET("/clusters/:clusterName/metrics/consumer-groups", metricsHandler.GetConsumerGroupMetrics)
+			// Metrics routes
+			cluster.GET("/metrics/consumer-lag", metricsHandler.GetConsumerGroupsLag)
+			cluster.GET("/metrics/cluster-health", metricsHandler.GetClusterHealth)
+			cluster.GET("/metrics/brokers", metricsHandler.GetBrokerMetrics)
+			cluster.GET("/metrics/topics", metricsHandler.GetTopicMetrics)
+			cluster.GET("/metrics/consumer-groups", metricsHandler.GetConsumerGroupMetrics)
+		}
 	}
 }
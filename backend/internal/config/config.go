@@ -1,20 +1,131 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/nikhilgoenkatech/kafka-ui/internal/constants"
 	"gopkg.in/yaml.v2"
 )
 
 type ClusterConfig struct {
-	Name    string   `yaml:"name"`
-	Brokers []string `yaml:"brokers"`
+	Name    string   `yaml:"name" json:"name"`
+	Brokers []string `yaml:"brokers" json:"brokers"`
+	Version string   `yaml:"version" json:"version,omitempty"`
+	Tags    []string `yaml:"tags" json:"tags,omitempty"`
+	// ReadOnly rejects every mutating request against the cluster.
+	ReadOnly bool       `yaml:"readOnly" json:"readOnly"`
+	TLS      TLSConfig  `yaml:"tls" json:"tls"`
+	SASL     SASLConfig `yaml:"sasl" json:"sasl"`
+	// Tuning overrides client timeouts and sizes; unset values use the defaults.
+	Tuning TuningConfig `yaml:"tuning" json:"tuning"`
 }
 
+// TLSConfig holds the TLS settings for a cluster. Certificates and keys can
+// be given inline as PEM or as paths to PEM files; inline values win.
+type TLSConfig struct {
+	Enabled            bool   `yaml:"enabled" json:"enabled"`
+	CACert             string `yaml:"caCert" json:"caCert,omitempty"`
+	CAFile             string `yaml:"caFile" json:"caFile,omitempty"`
+	ClientCert         string `yaml:"clientCert" json:"clientCert,omitempty"`
+	ClientCertFile     string `yaml:"clientCertFile" json:"clientCertFile,omitempty"`
+	ClientKey          string `yaml:"clientKey" json:"clientKey,omitempty"`
+	ClientKeyFile      string `yaml:"clientKeyFile" json:"clientKeyFile,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify" json:"insecureSkipVerify"`
+}
+
+// SASLConfig holds the SASL credentials for a cluster. Mechanism is one of
+// PLAIN, SCRAM-SHA-256, SCRAM-SHA-512 or OAUTHBEARER; empty disables SASL.
+// OAUTHBEARER tokens are fetched from TokenURL with the client credentials grant.
+type SASLConfig struct {
+	Mechanism    string   `yaml:"mechanism" json:"mechanism,omitempty"`
+	Username     string   `yaml:"username" json:"username,omitempty"`
+	Password     string   `yaml:"password" json:"password,omitempty"`
+	TokenURL     string   `yaml:"tokenUrl" json:"tokenUrl,omitempty"`
+	ClientID     string   `yaml:"clientId" json:"clientId,omitempty"`
+	ClientSecret string   `yaml:"clientSecret" json:"clientSecret,omitempty"`
+	Scopes       []string `yaml:"scopes" json:"scopes,omitempty"`
+}
+
+// Redacted returns a copy of the cluster definition with passwords, client
+// secrets and private keys removed, suitable for API responses.
+func (c ClusterConfig) Redacted() ClusterConfig {
+	c.Brokers = append([]string(nil), c.Brokers...)
+	c.TLS.ClientKey = ""
+	c.SASL.Password = ""
+	c.SASL.ClientSecret = ""
+	return c
+}
+
+// Config is the server configuration loaded from config.yml.
 type Config struct {
+	Server   ServerConfig    `yaml:"server"`
+	Auth     AuthConfig      `yaml:"auth"`
 	Clusters []ClusterConfig `yaml:"clusters"`
 }
 
+// ServerConfig holds the HTTP server settings.
+type ServerConfig struct {
+	// Host is the interface to listen on; empty listens on all interfaces.
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	// TLS serves HTTPS when a certificate and key are set.
+	TLS ServerTLSConfig `yaml:"tls"`
+	// BasePath prefixes every route, for running behind a reverse proxy
+	// under a sub path such as "/kafka-ui".
+	BasePath        string        `yaml:"basePath"`
+	ReadTimeout     time.Duration `yaml:"readTimeout"`
+	WriteTimeout    time.Duration `yaml:"writeTimeout"`
+	IdleTimeout     time.Duration `yaml:"idleTimeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// RateLimit is the number of requests allowed per minute per client IP.
+	// A negative value disables rate limiting.
+	RateLimit int `yaml:"rateLimit"`
+	// CORSOrigins lists the origins allowed to call the API. Empty or "*"
+	// allows every origin.
+	CORSOrigins []string `yaml:"corsOrigins"`
+}
+
+// ServerTLSConfig holds the certificate and key files used to serve HTTPS.
+type ServerTLSConfig struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+}
+
+// Enabled reports whether HTTPS is configured.
+func (t ServerTLSConfig) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+// Addr returns the host:port address to listen on.
+func (s ServerConfig) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// AuthConfig holds the settings used to sign and validate JWTs.
+type AuthConfig struct {
+	JWTSecret string `yaml:"jwtSecret"`
+}
+
+// Server defaults used when the configuration file leaves a setting unset.
+const (
+	DefaultPort            = 8080
+	DefaultReadTimeout     = 30 * time.Second
+	DefaultWriteTimeout    = 60 * time.Second
+	DefaultIdleTimeout     = 120 * time.Second
+	DefaultShutdownTimeout = 5 * time.Second
+	DefaultRateLimit       = 100
+)
+
+// LoadConfig reads the configuration file at path. ${VAR} and ${VAR:-default}
+// references are replaced with environment variables before parsing, and
+// secret fields of the form "file:/path" are replaced with the contents of
+// that file. The result is validated and missing settings are defaulted.
 func LoadConfig(path string) (*Config, error) {
 	config := &Config{}
 
@@ -23,10 +134,150 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
-	err = yaml.Unmarshal(file, config)
+	expanded, err := expandEnv(string(file))
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal([]byte(expanded), config)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := config.resolveSecretFiles(); err != nil {
+		return nil, err
+	}
+	config.applyDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
+
+func (c *Config) applyDefaults() {
+	if c.Server.Port == 0 {
+		c.Server.Port = DefaultPort
+	}
+	if c.Server.ReadTimeout == 0 {
+		c.Server.ReadTimeout = DefaultReadTimeout
+	}
+	if c.Server.WriteTimeout == 0 {
+		c.Server.WriteTimeout = DefaultWriteTimeout
+	}
+	if c.Server.IdleTimeout == 0 {
+		c.Server.IdleTimeout = DefaultIdleTimeout
+	}
+	if c.Server.ShutdownTimeout == 0 {
+		c.Server.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if c.Server.RateLimit == 0 {
+		c.Server.RateLimit = DefaultRateLimit
+	}
+	c.Server.BasePath = strings.TrimSuffix(c.Server.BasePath, "/")
+	if c.Auth.JWTSecret == "" {
+		c.Auth.JWTSecret = constants.SecretKeyDev
+	}
+}
+
+// Validate checks that every required setting is present and consistent.
+// All problems are reported together.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port: %d is not a valid port", c.Server.Port))
+	}
+	if (c.Server.TLS.CertFile == "") != (c.Server.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("server.tls: certFile and keyFile must be set together"))
+	}
+	if c.Server.BasePath != "" && !strings.HasPrefix(c.Server.BasePath, "/") {
+		errs = append(errs, fmt.Errorf("server.basePath: %q must start with /", c.Server.BasePath))
+	}
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"readTimeout", c.Server.ReadTimeout},
+		{"writeTimeout", c.Server.WriteTimeout},
+		{"idleTimeout", c.Server.IdleTimeout},
+		{"shutdownTimeout", c.Server.ShutdownTimeout},
+	} {
+		if timeout.value < 0 {
+			errs = append(errs, fmt.Errorf("server.%s: %s must not be negative", timeout.name, timeout.value))
+		}
+	}
+
+	names := make(map[string]bool, len(c.Clusters))
+	for i, cluster := range c.Clusters {
+		field := fmt.Sprintf("clusters[%d]", i)
+		if cluster.Name == "" {
+			errs = append(errs, fmt.Errorf("%s.name is required", field))
+		} else {
+			field = fmt.Sprintf("clusters[%d] (%s)", i, cluster.Name)
+			if names[cluster.Name] {
+				errs = append(errs, fmt.Errorf("%s: duplicate cluster name", field))
+			}
+			names[cluster.Name] = true
+		}
+		for _, err := range cluster.problems() {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the brokers and security settings of a cluster definition.
+func (c ClusterConfig) Validate() error {
+	return errors.Join(c.problems()...)
+}
+
+func (c ClusterConfig) problems() []error {
+	var errs []error
+	if len(c.Brokers) == 0 {
+		errs = append(errs, errors.New("brokers: at least one broker is required"))
+	}
+	for i, broker := range c.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			errs = append(errs, fmt.Errorf("brokers[%d]: %q is not a host:port address", i, broker))
+		}
+	}
+
+	if (c.TLS.ClientCert != "" || c.TLS.ClientCertFile != "") != (c.TLS.ClientKey != "" || c.TLS.ClientKeyFile != "") {
+		errs = append(errs, errors.New("tls: a client certificate and key must be given together"))
+	}
+
+	switch strings.ToUpper(c.SASL.Mechanism) {
+	case "":
+	case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+		if c.SASL.Username == "" {
+			errs = append(errs, fmt.Errorf("sasl.username is required for %s", c.SASL.Mechanism))
+		}
+	case "OAUTHBEARER":
+		if c.SASL.TokenURL == "" {
+			errs = append(errs, errors.New("sasl.tokenUrl is required for OAUTHBEARER"))
+		}
+		if c.SASL.ClientID == "" {
+			errs = append(errs, errors.New("sasl.clientId is required for OAUTHBEARER"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("sasl.mechanism: unsupported mechanism %q", c.SASL.Mechanism))
+	}
+	errs = append(errs, c.Tuning.problems()...)
+	return errs
+}
+
+// WithSecretsFrom returns a copy of the cluster definition in which empty
+// secrets are filled in from previous, so clients can edit a cluster without
+// resending credentials they cannot read back.
+func (c ClusterConfig) WithSecretsFrom(previous ClusterConfig) ClusterConfig {
+	if c.TLS.ClientKey == "" && c.TLS.ClientKeyFile == "" {
+		c.TLS.ClientKey = previous.TLS.ClientKey
+	}
+	if c.SASL.Password == "" && c.SASL.Username == previous.SASL.Username {
+		c.SASL.Password = previous.SASL.Password
+	}
+	if c.SASL.ClientSecret == "" && c.SASL.ClientID == previous.SASL.ClientID {
+		c.SASL.ClientSecret = previous.SASL.ClientSecret
+	}
+	return c
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envRef matches ${VAR} and ${VAR:-default}.
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// secretFilePrefix marks a secret value that should be read from a file,
+// such as a Kubernetes or Docker secret mount.
+const secretFilePrefix = "file:"
+
+// expandEnv replaces ${VAR} references in the values of a YAML document
+// with the value of the environment variable. ${VAR:-default} falls back to
+// default when VAR is unset or empty; a plain ${VAR} that is unset is an
+// error so a missing secret is not silently replaced by an empty string.
+//
+// References are expanded in the parsed document rather than the raw text,
+// so values containing quotes, backslashes, " #", ": " or newlines are kept
+// as is. Quoted values stay strings; unquoted ones are resolved again, so
+// port: ${PORT} still reads as a number. Comments are left as is.
+func expandEnv(text string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &document); err != nil {
+		return "", err
+	}
+	if document.Kind == 0 {
+		return text, nil
+	}
+
+	var missing []string
+	var expand func(node *yaml.Node)
+	expand = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range node.Content {
+				expand(child)
+			}
+		case yaml.MappingNode:
+			for i := 1; i < len(node.Content); i += 2 {
+				expand(node.Content[i])
+			}
+		case yaml.ScalarNode:
+			if !envRef.MatchString(node.Value) {
+				return
+			}
+			node.Value = envRef.ReplaceAllStringFunc(node.Value, func(ref string) string {
+				match := envRef.FindStringSubmatch(ref)
+				name, hasDefault, fallback := match[1], match[2] != "", match[3]
+				if value := os.Getenv(name); value != "" {
+					return value
+				}
+				if hasDefault {
+					return fallback
+				}
+				if _, set := os.LookupEnv(name); !set {
+					missing = append(missing, name)
+				}
+				return ""
+			})
+			if node.Style == 0 {
+				// Let an unquoted value resolve to a number or bool again.
+				node.Tag = ""
+			}
+		}
+	}
+	expand(&document)
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variables referenced in config are not set: %s", strings.Join(missing, ", "))
+	}
+
+	expanded, err := yaml.Marshal(&document)
+	if err != nil {
+		return "", err
+	}
+	return string(expanded), nil
+}
+
+// resolveSecretFiles replaces every secret of the form "file:/path" with the
+// contents of the file, without its trailing newline.
+func (c *Config) resolveSecretFiles() error {
+	var errs []error
+	resolve := func(field string, value *string) {
+		if err := readSecretFile(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+		}
+	}
+
+	resolve("auth.jwtSecret", &c.Auth.JWTSecret)
+	for i := range c.Clusters {
+		cluster := &c.Clusters[i]
+		prefix := fmt.Sprintf("clusters[%d]", i)
+		resolve(prefix+".tls.caCert", &cluster.TLS.CACert)
+		resolve(prefix+".tls.clientCert", &cluster.TLS.ClientCert)
+		resolve(prefix+".tls.clientKey", &cluster.TLS.ClientKey)
+		resolve(prefix+".sasl.username", &cluster.SASL.Username)
+		resolve(prefix+".sasl.password", &cluster.SASL.Password)
+		resolve(prefix+".sasl.clientId", &cluster.SASL.ClientID)
+		resolve(prefix+".sasl.clientSecret", &cluster.SASL.ClientSecret)
+	}
+	return errors.Join(errs...)
+}
+
+func readSecretFile(value *string) error {
+	path, ok := strings.CutPrefix(*value, secretFilePrefix)
+	if !ok {
+		return nil
+	}
+	if path == "" {
+		return errors.New("secret file path is empty")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read secret file: %w", err)
+	}
+	*value = strings.TrimRight(string(data), "\r\n")
+	return nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigExpandsEnv(t *testing.T) {
+	const secret = `pa"ss\word #1: x`
+	const pem = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+	t.Setenv("TEST_SECRET", secret)
+	t.Setenv("TEST_PEM", pem)
+	t.Setenv("TEST_PORT", "9090")
+	t.Setenv("TEST_EMPTY", "")
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	data := `# ${TEST_UNSET} in a comment is ignored
+server:
+  port: ${TEST_PORT}
+  readTimeout: ${TEST_EMPTY:-15s}
+auth:
+  jwtSecret: ${TEST_SECRET}
+clusters:
+  - name: "local"
+    brokers:
+      - "${TEST_HOST:-localhost}:9092"
+    tls:
+      enabled: true
+      caCert: "${TEST_PEM}"
+    sasl:
+      mechanism: PLAIN
+      username: 'user-${TEST_PORT}'
+      password: "${TEST_SECRET}"
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	cluster := config.Clusters[0]
+	checks := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"server.port", config.Server.Port, 9090},
+		{"server.readTimeout", config.Server.ReadTimeout.String(), "15s"},
+		{"auth.jwtSecret", config.Auth.JWTSecret, secret},
+		{"brokers[0]", cluster.Brokers[0], "localhost:9092"},
+		{"tls.caCert", cluster.TLS.CACert, pem},
+		{"sasl.username", cluster.SASL.Username, "user-9090"},
+		{"sasl.password", cluster.SASL.Password, secret},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %q, want %q", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestExpandEnvRejectsUnsetVariables(t *testing.T) {
+	_, err := expandEnv("auth:\n  jwtSecret: \"${TEST_UNSET_SECRET}\"\n")
+	if err == nil || !strings.Contains(err.Error(), "TEST_UNSET_SECRET") {
+		t.Fatalf("expandEnv() error = %v, want one naming TEST_UNSET_SECRET", err)
+	}
+}
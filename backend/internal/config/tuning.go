@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Default client tuning, used for every setting a cluster leaves unset.
+const (
+	defaultDialTimeout             = 5 * time.Second
+	defaultReadTimeout             = 30 * time.Second
+	defaultMessageTimeout          = 2 * time.Second
+	defaultLatestMessagesTimeout   = time.Second
+	defaultFetchTimeout            = 200 * time.Millisecond
+	defaultMetadataRefreshInterval = 10 * time.Minute
+	defaultMaxFetchBytes           = 1000000
+	defaultClientID                = "kafka-ui-backend"
+)
+
+// TuningConfig overrides the client timeouts and sizes used for a cluster.
+// Durations are written as Go duration strings such as "5s" or "250ms".
+type TuningConfig struct {
+	// DialTimeout bounds connecting to a broker.
+	DialTimeout Duration `yaml:"dialTimeout" json:"dialTimeout,omitempty"`
+	// ReadTimeout bounds waiting for a single broker response.
+	ReadTimeout Duration `yaml:"readTimeout" json:"readTimeout,omitempty"`
+	// MetadataTimeout bounds a metadata refresh including retries. Unset
+	// leaves it to the retry policy and ReadTimeout.
+	MetadataTimeout Duration `yaml:"metadataTimeout" json:"metadataTimeout,omitempty"`
+	// MetadataRefreshInterval is how often cluster metadata is refreshed in
+	// the background.
+	MetadataRefreshInterval Duration `yaml:"metadataRefreshInterval" json:"metadataRefreshInterval,omitempty"`
+	// MessageTimeout bounds reading messages for one browse request.
+	MessageTimeout Duration `yaml:"messageTimeout" json:"messageTimeout,omitempty"`
+	// LatestMessagesTimeout bounds reading the latest messages of a topic.
+	LatestMessagesTimeout Duration `yaml:"latestMessagesTimeout" json:"latestMessagesTimeout,omitempty"`
+	// FetchTimeout bounds a single fetch while browsing messages; a fetch
+	// that times out is taken as the end of the partition.
+	FetchTimeout Duration `yaml:"fetchTimeout" json:"fetchTimeout,omitempty"`
+	// MaxFetchBytes is the largest fetch response accepted per partition.
+	MaxFetchBytes int `yaml:"maxFetchBytes" json:"maxFetchBytes,omitempty"`
+	// ClientID identifies this application to the brokers.
+	ClientID string `yaml:"clientId" json:"clientId,omitempty"`
+}
+
+// WithDefaults returns a copy of the tuning with unset values defaulted.
+func (t TuningConfig) WithDefaults() TuningConfig {
+	if t.DialTimeout == 0 {
+		t.DialTimeout = Duration(defaultDialTimeout)
+	}
+	if t.ReadTimeout == 0 {
+		t.ReadTimeout = Duration(defaultReadTimeout)
+	}
+	if t.MetadataRefreshInterval == 0 {
+		t.MetadataRefreshInterval = Duration(defaultMetadataRefreshInterval)
+	}
+	if t.MessageTimeout == 0 {
+		t.MessageTimeout = Duration(defaultMessageTimeout)
+	}
+	if t.LatestMessagesTimeout == 0 {
+		t.LatestMessagesTimeout = Duration(defaultLatestMessagesTimeout)
+	}
+	if t.FetchTimeout == 0 {
+		t.FetchTimeout = Duration(defaultFetchTimeout)
+	}
+	if t.MaxFetchBytes == 0 {
+		t.MaxFetchBytes = defaultMaxFetchBytes
+	}
+	if t.ClientID == "" {
+		t.ClientID = defaultClientID
+	}
+	return t
+}
+
+func (t TuningConfig) problems() []error {
+	var errs []error
+	for _, d := range []struct {
+		name  string
+		value Duration
+	}{
+		{"dialTimeout", t.DialTimeout},
+		{"readTimeout", t.ReadTimeout},
+		{"metadataTimeout", t.MetadataTimeout},
+		{"metadataRefreshInterval", t.MetadataRefreshInterval},
+		{"messageTimeout", t.MessageTimeout},
+		{"latestMessagesTimeout", t.LatestMessagesTimeout},
+		{"fetchTimeout", t.FetchTimeout},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("tuning.%s: %s must not be negative", d.name, d.value))
+		}
+	}
+	if t.MaxFetchBytes < 0 || t.MaxFetchBytes > math.MaxInt32 {
+		errs = append(errs, fmt.Errorf("tuning.maxFetchBytes: %d must be between 0 and %d", t.MaxFetchBytes, math.MaxInt32))
+	}
+	return errs
+}
+
+// Duration is a time.Duration that reads and writes as a duration string
+// in YAML and JSON.
+type Duration time.Duration
+
+// String formats the duration like time.Duration.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// UnmarshalYAML parses a duration string such as "5s".
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.parse(s)
+}
+
+// MarshalYAML writes the duration as a string.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalJSON parses a duration string such as "5s".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+	return d.parse(s)
+}
+
+// MarshalJSON writes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) parse(s string) error {
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"time"
+)
+
+// WatchFile polls the file at path every interval and sends on the returned
+// channel when its contents change. Polling compares content hashes, so it
+// also catches editors and config maps that replace the file atomically.
+// Notifications are coalesced while a previous one is unread. Watching stops
+// when stop is closed.
+func WatchFile(path string, interval time.Duration, stop <-chan struct{}) <-chan struct{} {
+	changed := make(chan struct{}, 1)
+	last := fileHash(path)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				hash := fileHash(path)
+				// A missing or unreadable file is treated as unchanged, so a
+				// file being rewritten is not reported until it is back.
+				if hash == nil || bytes.Equal(hash, last) {
+					continue
+				}
+				last = hash
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return changed
+}
+
+func fileHash(path string) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
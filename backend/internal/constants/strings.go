@@ -2,7 +2,7 @@ package constants
 
 const (
 	// BrokerService
-	ErrDescribeCluster = "failed to describe cluster %s"
+	ErrDescribeCluster = "failed to describe cluster %s: %w"
 	ErrNoBrokersFound  = "no brokers found for cluster %s"
 	BrokerIDKey        = "id"
 	BrokerAddrKey      = "addr"
@@ -43,9 +43,38 @@ const (
 	MsgReplicasGreaterThanZero           = "Replicas must be greater than 0"
 	MsgFailedToCreateTopic               = "Failed to create topic: "
 	MsgTopicCreatedSuccessfullyFmt       = "Topic %s created successfully"
+	MsgTopicCreateValidatedFmt           = "Topic %s can be created"
+	MsgInvalidReplicaAssignment          = "Invalid replica assignment: "
 	MsgCannotDeleteSystemTopic           = "Cannot delete system topic"
 	MsgFailedToDeleteTopic               = "Failed to delete topic: "
 	MsgTopicDeletedSuccessfullyFmt       = "Topic %s deleted successfully"
+	MsgFailedToIncreasePartitions        = "Failed to increase partitions: "
+	MsgPartitionsIncreasedFmt            = "Topic %s now has %d partitions"
+	MsgPartitionIncreaseValidatedFmt     = "Topic %s can be grown to %d partitions"
+	MsgInvalidTopicConfig                = "Invalid topic config: "
+	MsgFailedToUpdateTopicConfigs        = "Failed to update topic configs: "
+	MsgTopicConfigsUpdatedFmt            = "Configs of topic %s updated successfully"
+	MsgTopicConfigsValidatedFmt          = "Config changes for topic %s are valid"
+	MsgFailedToDeleteRecords             = "Failed to delete records: "
+	MsgRecordsDeletedFmt                 = "Deleted %d records from topic %s"
+	MsgRecordDeletionDryRunFmt           = "%d records would be deleted from topic %s"
+
+	// ReassignmentHandler
+	MsgFailedToGetReassignments        = "Failed to get partition reassignments: "
+	MsgReassignmentsRetrieved          = "Partition reassignments retrieved successfully"
+	MsgFailedToSubmitReassignment      = "Failed to submit partition reassignment: "
+	MsgReassignmentSubmittedFmt        = "Reassignment of %d partitions submitted"
+	MsgFailedToCancelReassignments     = "Failed to cancel partition reassignments: "
+	MsgReassignmentsCancelledFmt       = "Cancelled the reassignment of %d partitions"
+	MsgFailedToRemoveThrottle          = "Failed to remove replication throttle: "
+	MsgReplicationThrottleRemoved      = "Replication throttle removed"
+	MsgFailedToChangeReplicationFactor = "Failed to change replication factor: "
+	MsgReplicationFactorChangingFmt    = "Replication factor of topic %s is being changed to %d"
+	MsgReplicationFactorPlannedFmt     = "Plan to change the replication factor of topic %s to %d"
+
+	// LeaderElectionHandler
+	MsgFailedToElectLeaders = "Failed to elect leaders: "
+	MsgLeaderElectionFmt    = "%s leader election ran for %d partitions"
 
 	// Middleware/Auth
 	AuthHeaderPrefix = "Bearer "
@@ -65,9 +94,11 @@ const (
 	HeaderAccessControlAllowCredentials = "Access-Control-Allow-Credentials"
 	HeaderAccessControlAllowHeaders     = "Access-Control-Allow-Headers"
 	HeaderAccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	HeaderOrigin                        = "Origin"
+	HeaderVary                          = "Vary"
 	HeaderValueAllowOriginAll           = "*"
 	HeaderValueAllowCredentialsTrue     = "true"
 	HeaderValueAllowHeaders             = "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With"
-	HeaderValueAllowMethods             = "POST, OPTIONS, GET, PUT, DELETE"
+	HeaderValueAllowMethods             = "POST, OPTIONS, GET, PUT, PATCH, DELETE"
 	MsgRateLimitExceeded                = "Rate limit exceeded"
 )
@@ -3,56 +3,325 @@ package kafka
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/models"
+	"github.com/segmentio/kafka-go"
 )
 
+// drainTimeout is how long replaced clients stay open so requests that
+// already hold them can finish.
+const drainTimeout = 30 * time.Second
+
+// newSaramaConfig builds the sarama config shared by every connection to a cluster.
+func newSaramaConfig(sec *security, version sarama.KafkaVersion) *sarama.Config {
+	cfg := sarama.NewConfig()
+	cfg.Version = version
+	cfg.ClientID = sec.tuning.ClientID
+	// The dial timeout prevents requests from hanging indefinitely on an invalid address.
+	cfg.Net.DialTimeout = time.Duration(sec.tuning.DialTimeout)
+	cfg.Net.ReadTimeout = time.Duration(sec.tuning.ReadTimeout)
+	cfg.Metadata.Timeout = time.Duration(sec.tuning.MetadataTimeout)
+	cfg.Metadata.RefreshFrequency = time.Duration(sec.tuning.MetadataRefreshInterval)
+	cfg.Consumer.Fetch.Max = int32(sec.tuning.MaxFetchBytes)
+	// Required by the pooled sync producer.
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Partitioner = newExplicitPartitioner
+	sec.applySarama(cfg)
+	return cfg
+}
+
+// connection holds the pooled clients of a connected cluster. The client,
+// admin and producer are shared by every service and live until the cluster
+// is removed, rebuilt by the health monitor or the Service is closed.
+type connection struct {
+	security *security
+	version  sarama.KafkaVersion
+	client   sarama.Client
+	admin    sarama.ClusterAdmin
+	producer sarama.SyncProducer
+	metadata clusterMetadata
+}
+
+// managedCluster holds the definition and health of a single managed cluster.
+// conn is nil while the cluster is disconnected.
+type managedCluster struct {
+	config config.ClusterConfig
+	conn   *connection
+	health clusterHealth
+}
+
 // Service manages multiple Kafka cluster clients.
 type Service struct {
-	clients map[string]sarama.ClusterAdmin
-	brokers map[string][]string
-	mu      sync.RWMutex
+	clusters map[string]*managedCluster
+	static   map[string]bool
+	store    ClusterStore
+	mu       sync.RWMutex
+	// reloadMu serializes configuration reloads.
+	reloadMu sync.Mutex
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-// NewService creates a new Kafka service manager.
-func NewService() *Service {
+// NewService creates a new Kafka service manager. Clusters added at runtime
+// are written through to store; a nil store keeps them in memory only.
+func NewService(store ClusterStore) *Service {
 	return &Service{
-		clients: make(map[string]sarama.ClusterAdmin),
-		brokers: make(map[string][]string),
+		clusters: make(map[string]*managedCluster),
+		static:   make(map[string]bool),
+		store:    store,
+		stop:     make(chan struct{}),
 	}
 }
 
-// AddCluster connects to a new Kafka cluster and adds it to the manager.
-func (s *Service) AddCluster(name string, brokers []string) error {
+// AddCluster connects to a new Kafka cluster, adds it to the manager and
+// persists it to the cluster store. The connection is opened without holding
+// the lock, so an unreachable cluster does not stall requests to the others.
+func (s *Service) AddCluster(cluster config.ClusterConfig) error {
+	if err := s.checkNewName(cluster.Name); err != nil {
+		return err
+	}
+
+	conn, err := openConnection(cluster)
+	if err != nil {
+		return err
+	}
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	if err := s.checkNewNameLocked(cluster.Name); err != nil {
+		s.mu.Unlock()
+		conn.close()
+		return err
+	}
+	if s.store != nil {
+		if err := s.store.Save(cluster); err != nil {
+			s.mu.Unlock()
+			conn.close()
+			return fmt.Errorf("failed to persist cluster %s: %w", cluster.Name, err)
+		}
+	}
+	s.clusters[cluster.Name] = &managedCluster{
+		config: cluster,
+		conn:   conn,
+		health: newClusterHealth(nil),
+	}
+	s.mu.Unlock()
+	return nil
+}
 
-	if _, exists := s.clients[name]; exists {
+// checkNewName reports whether name can be used for a new runtime cluster.
+func (s *Service) checkNewName(name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.checkNewNameLocked(name)
+}
+
+// checkNewNameLocked is checkNewName for callers that hold s.mu.
+func (s *Service) checkNewNameLocked(name string) error {
+	if s.static[name] {
+		return fmt.Errorf("cluster name '%s' is reserved by the configuration file", name)
+	}
+	if _, exists := s.clusters[name]; exists {
 		return fmt.Errorf("cluster with name '%s' already exists", name)
 	}
+	return nil
+}
+
+// AddStaticCluster connects to a cluster defined in the configuration file.
+// A cluster that fails to connect is still registered as disconnected so the
+// health monitor can retry it, and static clusters cannot be removed through
+// the API.
+func (s *Service) AddStaticCluster(cluster config.ClusterConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.static[cluster.Name] = true
+	return s.register(cluster)
+}
+
+// RestoreClusters reconnects the clusters saved in the cluster store.
+// Stored clusters whose name is taken by a configuration file cluster are
+// skipped, since the configuration file takes precedence. Clusters that fail
+// to connect are kept as disconnected for the health monitor to retry.
+func (s *Service) RestoreClusters() (int, []error) {
+	if s.store == nil {
+		return 0, nil
+	}
+
+	clusters, err := s.store.List()
+	if err != nil {
+		return 0, []error{fmt.Errorf("failed to read cluster store: %w", err)}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	restored := 0
+	var errs []error
+	for _, cluster := range clusters {
+		if s.static[cluster.Name] {
+			errs = append(errs, fmt.Errorf("stored cluster '%s' ignored: name is defined in the configuration file", cluster.Name))
+			continue
+		}
+		if err := s.register(cluster); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		restored++
+	}
+	return restored, errs
+}
+
+// register opens the connections for a cluster and registers it, keeping it
+// as disconnected when the connection fails. The caller must hold s.mu.
+func (s *Service) register(cluster config.ClusterConfig) error {
+	if _, exists := s.clusters[cluster.Name]; exists {
+		return fmt.Errorf("cluster with name '%s' already exists", cluster.Name)
+	}
+
+	conn, err := openConnection(cluster)
+	s.clusters[cluster.Name] = &managedCluster{
+		config: cluster,
+		conn:   conn,
+		health: newClusterHealth(err),
+	}
+	return err
+}
+
+// disconnect closes and unregisters a cluster's clients.
+// The caller must hold s.mu.
+func (s *Service) disconnect(name string) error {
+	c, exists := s.clusters[name]
+	if !exists {
+		return nil
+	}
 
-	config := sarama.NewConfig()
-	config.Version = sarama.V2_5_0_0 // A more modern, safe default
-	config.ClientID = "kafka-ui-backend"
-	// Add a timeout to prevent the request from hanging indefinitely on an invalid address.
-	config.Net.DialTimeout = 5 * time.Second
+	delete(s.clusters, name)
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.close()
+}
+
+// openConnection creates the pooled client, admin and producer for a cluster
+// and verifies that the cluster is reachable.
+func openConnection(cluster config.ClusterConfig) (*connection, error) {
+	name := cluster.Name
+	sec, err := newSecurity(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s: %w", name, err)
+	}
 
-	admin, err := sarama.NewClusterAdmin(brokers, config)
+	version, err := resolveVersion(cluster.Version, cluster.Brokers, sec)
 	if err != nil {
-		return fmt.Errorf("failed to create cluster admin for %s: %w", name, err)
+		return nil, fmt.Errorf("failed to connect to cluster %s: %w", name, err)
+	}
+
+	client, err := sarama.NewClient(cluster.Brokers, newSaramaConfig(sec, version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cluster %s: %w", name, err)
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to create cluster admin for %s: %w", name, err)
 	}
 
 	// Test the connection by listing topics. This is a lightweight way to verify connectivity.
 	_, err = admin.ListTopics()
 	if err != nil {
 		admin.Close() // Clean up the failed connection
-		return fmt.Errorf("failed to connect to cluster %s: %w", name, err)
+		return nil, fmt.Errorf("failed to connect to cluster %s: %w", name, err)
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		admin.Close()
+		return nil, fmt.Errorf("failed to create producer for %s: %w", name, err)
+	}
+
+	conn := &connection{
+		security: sec,
+		version:  version,
+		client:   client,
+		admin:    admin,
+		producer: producer,
+	}
+	// Metadata is informational; the health monitor fills it in if this fails.
+	conn.metadata, _ = probe(conn)
+	return conn, nil
+}
+
+// close shuts down the producer, then the admin and its underlying client.
+func (c *connection) close() error {
+	producerErr := c.producer.Close()
+	if err := c.admin.Close(); err != nil {
+		return err
+	}
+	return producerErr
+}
+
+// UpdateCluster replaces the connection settings of a runtime-added cluster.
+// The new settings are verified on a fresh connection first; the clients are
+// only swapped in on success, and the old ones are closed after a grace
+// period so in-flight requests can finish.
+func (s *Service) UpdateCluster(cluster config.ClusterConfig) error {
+	s.mu.RLock()
+	c, exists := s.clusters[cluster.Name]
+	var previous config.ClusterConfig
+	if exists {
+		previous = c.config
+	}
+	static := s.static[cluster.Name]
+	s.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("cluster '%s' not found", cluster.Name)
+	}
+	if static {
+		return fmt.Errorf("cluster '%s' is defined in the configuration file and cannot be edited", cluster.Name)
+	}
+	if previous.ReadOnly && !cluster.ReadOnly {
+		return fmt.Errorf("cluster '%s' is read-only and the flag cannot be cleared", cluster.Name)
 	}
+	cluster = cluster.WithSecretsFrom(previous)
+	if err := cluster.Validate(); err != nil {
+		return fmt.Errorf("invalid cluster settings: %w", err)
+	}
+
+	conn, err := openConnection(cluster)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.clusters[cluster.Name] != c {
+		s.mu.Unlock()
+		conn.close()
+		return fmt.Errorf("cluster '%s' was modified concurrently", cluster.Name)
+	}
+	if s.store != nil {
+		if err := s.store.Save(cluster); err != nil {
+			s.mu.Unlock()
+			conn.close()
+			return fmt.Errorf("failed to persist cluster %s: %w", cluster.Name, err)
+		}
+	}
+	old := c.conn
+	c.config = cluster
+	c.conn = conn
+	c.health = newClusterHealth(nil)
+	s.mu.Unlock()
 
-	s.clients[name] = admin
-	s.brokers[name] = brokers
+	if old != nil {
+		retire(old)
+	}
 	return nil
 }
 
@@ -61,26 +330,46 @@ func (s *Service) RemoveCluster(name string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	client, exists := s.clients[name]
-	if !exists {
+	if _, exists := s.clusters[name]; !exists {
 		return fmt.Errorf("cluster '%s' not found", name)
 	}
+	if s.static[name] {
+		return fmt.Errorf("cluster '%s' is defined in the configuration file and cannot be removed", name)
+	}
+	if s.clusters[name].config.ReadOnly {
+		return fmt.Errorf("cluster '%s' is read-only and cannot be removed", name)
+	}
 
-	delete(s.clients, name)
-	delete(s.brokers, name)
-	return client.Close()
+	if s.store != nil {
+		if err := s.store.Delete(name); err != nil {
+			return fmt.Errorf("failed to remove cluster %s from store: %w", name, err)
+		}
+	}
+	return s.disconnect(name)
 }
 
-// GetClient retrieves a client for a specific cluster.
-func (s *Service) GetClient(clusterName string) (sarama.ClusterAdmin, error) {
+// connection returns the pooled clients of a connected cluster.
+func (s *Service) connection(clusterName string) (*connection, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	client, exists := s.clients[clusterName]
+	c, exists := s.clusters[clusterName]
 	if !exists {
 		return nil, fmt.Errorf("client for cluster '%s' not found", clusterName)
 	}
-	return client, nil
+	if c.conn == nil {
+		return nil, fmt.Errorf("cluster '%s' is disconnected: %s", clusterName, c.health.lastError)
+	}
+	return c.conn, nil
+}
+
+// GetClient retrieves a client for a specific cluster.
+func (s *Service) GetClient(clusterName string) (sarama.ClusterAdmin, error) {
+	conn, err := s.connection(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return conn.admin, nil
 }
 
 // GetBrokers retrieves the broker list for a specific cluster.
@@ -88,30 +377,179 @@ func (s *Service) GetBrokers(clusterName string) ([]string, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	brokers, exists := s.brokers[clusterName]
+	c, exists := s.clusters[clusterName]
 	if !exists {
 		return nil, fmt.Errorf("brokers for cluster '%s' not found", clusterName)
 	}
-	return brokers, nil
+	return c.config.Brokers, nil
+}
+
+// GetSaramaClient retrieves the pooled sarama client for a specific cluster.
+// Callers must not close it.
+func (s *Service) GetSaramaClient(clusterName string) (sarama.Client, error) {
+	conn, err := s.connection(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return conn.client, nil
+}
+
+// GetProducer retrieves the pooled sync producer for a specific cluster.
+// Callers must not close it.
+func (s *Service) GetProducer(clusterName string) (sarama.SyncProducer, error) {
+	conn, err := s.connection(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return conn.producer, nil
+}
+
+// readerConfig returns a kafka-go reader config for a cluster with the
+// brokers, dialer and fetch size already set.
+func (s *Service) readerConfig(clusterName string) (kafka.ReaderConfig, error) {
+	brokers, err := s.GetBrokers(clusterName)
+	if err != nil {
+		return kafka.ReaderConfig{}, err
+	}
+	conn, err := s.connection(clusterName)
+	if err != nil {
+		return kafka.ReaderConfig{}, err
+	}
+	dialer, err := conn.security.dialer()
+	if err != nil {
+		return kafka.ReaderConfig{}, err
+	}
+	return kafka.ReaderConfig{
+		Brokers:  brokers,
+		Dialer:   dialer,
+		MaxBytes: conn.security.tuning.MaxFetchBytes,
+		MinBytes: 1, // Don't wait for batches
+	}, nil
+}
+
+// tuning returns the client tuning of a connected cluster.
+func (s *Service) tuning(clusterName string) (config.TuningConfig, error) {
+	conn, err := s.connection(clusterName)
+	if err != nil {
+		return config.TuningConfig{}, err
+	}
+	return conn.security.tuning, nil
+}
+
+// HasCluster reports whether a cluster is registered, connected or not.
+func (s *Service) HasCluster(clusterName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.clusters[clusterName]
+	return exists
+}
+
+// IsStatic reports whether a cluster was loaded from the configuration file.
+func (s *Service) IsStatic(clusterName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.static[clusterName]
 }
 
-// ListClusters returns the names of all managed clusters.
-func (s *Service) ListClusters() []string {
+// IsReadOnly reports whether a cluster is marked read-only. Unknown clusters
+// are not read-only; requests against them fail on lookup instead.
+func (s *Service) IsReadOnly(clusterName string) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	names := make([]string, 0, len(s.clients))
-	for name := range s.clients {
-		names = append(names, name)
+	c, exists := s.clusters[clusterName]
+	return exists && c.config.ReadOnly
+}
+
+// KafkaVersion returns the protocol version negotiated with a cluster, so
+// callers can gate features on it.
+func (s *Service) KafkaVersion(clusterName string) (sarama.KafkaVersion, error) {
+	conn, err := s.connection(clusterName)
+	if err != nil {
+		return sarama.KafkaVersion{}, err
 	}
-	return names
+	return conn.version, nil
 }
 
-// Close gracefully closes all cluster connections.
+// ListClusters returns all managed clusters sorted by name, using the
+// metadata from the last health check.
+func (s *Service) ListClusters() []models.Cluster {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clusters := make([]models.Cluster, 0, len(s.clusters))
+	for _, c := range s.clusters {
+		clusters = append(clusters, s.describe(c))
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters
+}
+
+// GetCluster returns the details of a single cluster and its redacted
+// definition. Metadata is fetched live when the cluster is connected.
+func (s *Service) GetCluster(clusterName string) (*models.Cluster, error) {
+	s.mu.RLock()
+	c, exists := s.clusters[clusterName]
+	var conn *connection
+	if exists {
+		conn = c.conn
+	}
+	s.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("cluster '%s' not found", clusterName)
+	}
+
+	if conn != nil {
+		if metadata, err := probe(conn); err == nil {
+			s.mu.Lock()
+			if c.conn == conn {
+				conn.metadata = metadata
+			}
+			s.mu.Unlock()
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cluster := s.describe(c)
+	definition := c.config.Redacted()
+	cluster.Config = &definition
+	return &cluster, nil
+}
+
+// describe converts a managed cluster to its API representation.
+// The caller must hold s.mu.
+func (s *Service) describe(c *managedCluster) models.Cluster {
+	cluster := models.Cluster{
+		Name:             c.config.Name,
+		BootstrapServers: strings.Join(c.config.Brokers, ","),
+		Tags:             append([]string{}, c.config.Tags...),
+		Static:           s.static[c.config.Name],
+		ReadOnly:         c.config.ReadOnly,
+		ControllerID:     -1,
+		Status:           c.health.status(),
+	}
+	if c.conn != nil {
+		cluster.KafkaVersion = c.conn.version.String()
+		cluster.ClusterID = c.conn.metadata.clusterID
+		cluster.ControllerID = c.conn.metadata.controllerID
+		cluster.BrokerCount = c.conn.metadata.brokerCount
+		cluster.TopicCount = c.conn.metadata.topicCount
+	}
+	return cluster
+}
+
+// Close stops the health monitor and gracefully closes all cluster connections.
 func (s *Service) Close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	for _, client := range s.clients {
-		client.Close()
+	for name := range s.clusters {
+		s.disconnect(name)
 	}
 }
@@ -0,0 +1,212 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+)
+
+// DiagnosticStep is the outcome of a single connection test step.
+type DiagnosticStep struct {
+	Name       string `json:"name"`
+	Target     string `json:"target,omitempty"`
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// ConnectionTestResult is the step-by-step report of a connection test.
+type ConnectionTestResult struct {
+	Success      bool             `json:"success"`
+	KafkaVersion string           `json:"kafkaVersion,omitempty"`
+	Steps        []DiagnosticStep `json:"steps"`
+}
+
+// connectionTest accumulates the steps of a connection test.
+type connectionTest struct {
+	ctx    context.Context
+	result *ConnectionTestResult
+}
+
+// run executes a step and records its result. It returns whether the step succeeded.
+func (t *connectionTest) run(name, target string, fn func() (string, error)) bool {
+	start := time.Now()
+	message, err := fn()
+	step := DiagnosticStep{
+		Name:       name,
+		Target:     target,
+		Success:    err == nil,
+		Message:    message,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		step.Message = err.Error()
+	}
+	t.result.Steps = append(t.result.Steps, step)
+	return err == nil
+}
+
+// TestConnection runs the same connection logic as AddCluster without
+// registering the cluster, and reports each step: DNS resolution, TCP dial
+// and TLS handshake per bootstrap broker, SASL authentication, metadata
+// fetch, reachability of every advertised listener, and finally the full
+// admin connection.
+func (s *Service) TestConnection(ctx context.Context, cluster config.ClusterConfig) *ConnectionTestResult {
+	t := &connectionTest{ctx: ctx, result: &ConnectionTestResult{Steps: []DiagnosticStep{}}}
+
+	var sec *security
+	if !t.run("config", "", func() (string, error) {
+		if err := cluster.Validate(); err != nil {
+			return "", err
+		}
+		var err error
+		sec, err = newSecurity(cluster)
+		return "settings are valid", err
+	}) {
+		return t.result
+	}
+
+	var reachable []string
+	for _, addr := range cluster.Brokers {
+		if t.checkAddress(addr, sec, "") {
+			reachable = append(reachable, addr)
+		}
+	}
+	if len(reachable) == 0 {
+		return t.result
+	}
+
+	authStep := "api_versions"
+	if sec.sasl.Mechanism != "" {
+		authStep = "sasl"
+	}
+	var version sarama.KafkaVersion
+	if !t.run(authStep, reachable[0], func() (string, error) {
+		detected, err := detectVersion(reachable, sec)
+		if err != nil {
+			return "", err
+		}
+		version = detected
+		if cluster.Version != "" {
+			if version, err = resolveVersion(cluster.Version, nil, sec); err != nil {
+				return "", err
+			}
+		}
+		if sec.sasl.Mechanism != "" {
+			return fmt.Sprintf("authenticated with %s, broker supports Kafka %s", sec.sasl.Mechanism, detected), nil
+		}
+		return fmt.Sprintf("broker supports Kafka %s", detected), nil
+	}) {
+		return t.result
+	}
+	t.result.KafkaVersion = version.String()
+
+	var advertised []*sarama.Broker
+	if !t.run("metadata", reachable[0], func() (string, error) {
+		broker := sarama.NewBroker(reachable[0])
+		if err := broker.Open(newSaramaConfig(sec, version)); err != nil {
+			return "", err
+		}
+		defer broker.Close()
+
+		resp, err := broker.GetMetadata(sarama.NewMetadataRequest(version, nil))
+		if err != nil {
+			return "", err
+		}
+		advertised = resp.Brokers
+		return fmt.Sprintf("%d brokers, %d topics, controller %d", len(resp.Brokers), len(resp.Topics), resp.ControllerID), nil
+	}) {
+		return t.result
+	}
+
+	allAdvertised := true
+	for _, broker := range advertised {
+		label := fmt.Sprintf("broker %d", broker.ID())
+		if !t.checkAddress(broker.Addr(), sec, label) {
+			allAdvertised = false
+		}
+	}
+	if !allAdvertised {
+		return t.result
+	}
+
+	if t.run("connect", strings.Join(cluster.Brokers, ","), func() (string, error) {
+		conn, err := openConnection(cluster)
+		if err != nil {
+			return "", err
+		}
+		conn.close()
+		return "cluster admin connected and listed topics", nil
+	}) {
+		t.result.Success = true
+	}
+	return t.result
+}
+
+// checkAddress resolves, dials and, if TLS is enabled, handshakes with a
+// broker address. label prefixes the step names for advertised listeners.
+func (t *connectionTest) checkAddress(addr string, sec *security, label string) bool {
+	prefix := ""
+	target := addr
+	if label != "" {
+		prefix = "advertised_"
+		target = label + " at " + addr
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if !t.run(prefix+"dns", target, func() (string, error) {
+		if err != nil {
+			return "", fmt.Errorf("invalid address: %w", err)
+		}
+		ips, err := net.DefaultResolver.LookupHost(t.ctx, host)
+		if err != nil {
+			return "", err
+		}
+		return "resolved to " + strings.Join(ips, ", "), nil
+	}) {
+		return false
+	}
+
+	var conn net.Conn
+	if !t.run(prefix+"tcp", target, func() (string, error) {
+		dialer := &net.Dialer{Timeout: time.Duration(sec.tuning.DialTimeout)}
+		var err error
+		conn, err = dialer.DialContext(t.ctx, "tcp", addr)
+		if err != nil {
+			return "", err
+		}
+		return "connected to " + conn.RemoteAddr().String(), nil
+	}) {
+		return false
+	}
+	defer conn.Close()
+
+	if sec.tls == nil {
+		return true
+	}
+	return t.run(prefix+"tls", target, func() (string, error) {
+		tlsConfig := sec.tls.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = host
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		ctx, cancel := context.WithTimeout(t.ctx, time.Duration(sec.tuning.DialTimeout))
+		defer cancel()
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return "", err
+		}
+
+		state := tlsConn.ConnectionState()
+		message := "handshake completed using " + tls.VersionName(state.Version)
+		if len(state.PeerCertificates) > 0 {
+			message += ", server certificate " + state.PeerCertificates[0].Subject.CommonName
+		}
+		return message, nil
+	})
+}
@@ -0,0 +1,217 @@
+package kafka
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/models"
+)
+
+// Cluster connection states reported by the health monitor.
+const (
+	StateConnected    = "connected"
+	StateDegraded     = "degraded"
+	StateDisconnected = "disconnected"
+)
+
+// maxProbeFailures is the number of consecutive failed probes after which a
+// degraded cluster is considered disconnected and its clients are rebuilt.
+const maxProbeFailures = 3
+
+// clusterHealth tracks the connection state of a cluster between probes.
+type clusterHealth struct {
+	state         string
+	since         time.Time
+	lastChecked   time.Time
+	lastConnected time.Time
+	lastError     string
+	lastErrorAt   time.Time
+	failures      int
+}
+
+// newClusterHealth returns the initial health after a connection attempt.
+func newClusterHealth(err error) clusterHealth {
+	now := time.Now()
+	h := clusterHealth{}
+	if err != nil {
+		h.setState(StateDisconnected, now)
+	}
+	h.record(err, now)
+	return h
+}
+
+// record updates the health with the result of a probe or reconnect attempt.
+func (h *clusterHealth) record(err error, now time.Time) {
+	h.lastChecked = now
+	if err == nil {
+		h.failures = 0
+		h.lastConnected = now
+		h.setState(StateConnected, now)
+		return
+	}
+
+	h.failures++
+	h.lastError = err.Error()
+	h.lastErrorAt = now
+	if h.state == StateDisconnected || h.failures >= maxProbeFailures {
+		h.setState(StateDisconnected, now)
+	} else {
+		h.setState(StateDegraded, now)
+	}
+}
+
+func (h *clusterHealth) setState(state string, now time.Time) {
+	if h.state != state {
+		h.state = state
+		h.since = now
+	}
+}
+
+// status converts the health to its API representation.
+func (h *clusterHealth) status() models.ClusterStatus {
+	status := models.ClusterStatus{
+		State:         h.state,
+		StateSince:    h.since,
+		LastCheckedAt: h.lastChecked,
+		LastError:     h.lastError,
+	}
+	if !h.lastConnected.IsZero() {
+		connectedAt := h.lastConnected
+		status.LastConnectedAt = &connectedAt
+	}
+	if !h.lastErrorAt.IsZero() {
+		errorAt := h.lastErrorAt
+		status.LastErrorAt = &errorAt
+	}
+	return status
+}
+
+// StartHealthMonitor probes every cluster at the given interval until Close
+// is called. Disconnected clusters are reconnected when they recover.
+func (s *Service) StartHealthMonitor(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.checkClusters()
+			}
+		}
+	}()
+}
+
+// checkClusters probes all clusters concurrently.
+func (s *Service) checkClusters() {
+	s.mu.RLock()
+	targets := make(map[string]*managedCluster, len(s.clusters))
+	for name, c := range s.clusters {
+		targets[name] = c
+	}
+	s.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for name, c := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.checkCluster(name, c)
+		}()
+	}
+	wg.Wait()
+}
+
+// checkCluster probes a connected cluster, or tries to reconnect a
+// disconnected one. Network calls are made without holding s.mu; the result
+// is discarded if the cluster was removed or replaced in the meantime.
+func (s *Service) checkCluster(name string, c *managedCluster) {
+	s.mu.RLock()
+	conn, cluster := c.conn, c.config
+	s.mu.RUnlock()
+
+	if conn == nil {
+		newConn, err := openConnection(cluster)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.clusters[name] != c || c.conn != nil {
+			if newConn != nil {
+				newConn.close()
+			}
+			return
+		}
+		c.health.record(err, time.Now())
+		if err == nil {
+			c.conn = newConn
+			log.Printf("Cluster %s reconnected", name)
+		}
+		return
+	}
+
+	metadata, err := probe(conn)
+
+	s.mu.Lock()
+	if s.clusters[name] != c || c.conn != conn {
+		s.mu.Unlock()
+		return
+	}
+	previous := c.health.state
+	c.health.record(err, time.Now())
+	if err == nil {
+		conn.metadata = metadata
+	}
+	state := c.health.state
+	if state == StateDisconnected {
+		c.conn = nil
+	}
+	s.mu.Unlock()
+
+	if state != previous {
+		log.Printf("Cluster %s is %s: %v", name, state, err)
+	}
+	if state == StateDisconnected {
+		conn.close()
+	}
+}
+
+// clusterMetadata is a snapshot of cluster-wide metadata taken by the
+// health monitor.
+type clusterMetadata struct {
+	clusterID    string
+	controllerID int32
+	brokerCount  int
+	topicCount   int
+}
+
+// probe fetches cluster metadata from the controller. A failure to find the
+// controller or to fetch metadata counts as a failed health check.
+func probe(conn *connection) (clusterMetadata, error) {
+	controller, err := conn.client.Controller()
+	if err != nil {
+		return clusterMetadata{}, err
+	}
+
+	resp, err := controller.GetMetadata(sarama.NewMetadataRequest(conn.version, nil))
+	if err != nil {
+		return clusterMetadata{}, err
+	}
+	if len(resp.Brokers) == 0 {
+		return clusterMetadata{}, errors.New("no brokers available")
+	}
+
+	metadata := clusterMetadata{
+		controllerID: resp.ControllerID,
+		brokerCount:  len(resp.Brokers),
+		topicCount:   len(resp.Topics),
+	}
+	if resp.ClusterID != nil {
+		metadata.clusterID = *resp.ClusterID
+	}
+	return metadata, nil
+}
@@ -0,0 +1,265 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+)
+
+// Leader election types.
+const (
+	ElectionPreferred = "preferred"
+	ElectionUnclean   = "unclean"
+)
+
+// Per-partition leader election outcomes.
+const (
+	ElectionElected   = "elected"
+	ElectionNotNeeded = "not_needed"
+	ElectionFailed    = "failed"
+)
+
+// LeaderElectionRequest runs a leader election. The scope is the listed
+// Partitions, otherwise every partition of Topic, otherwise the whole
+// cluster. A preferred election moves leadership back to the first replica
+// of each partition; an unclean election picks a leader for offline
+// partitions from out-of-sync replicas, losing the records they lack, and
+// must be confirmed with ConfirmDataLoss.
+type LeaderElectionRequest struct {
+	Type            string           `json:"type"`
+	Topic           string           `json:"topic"`
+	Partitions      []TopicPartition `json:"partitions"`
+	ConfirmDataLoss bool             `json:"confirmDataLoss"`
+}
+
+// PartitionElectionResult is the outcome of the election of one partition.
+type PartitionElectionResult struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Status    string `json:"status"`
+	Leader    int32  `json:"leader"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BrokerLeaders is the number of partitions a broker leads before and
+// after an election.
+type BrokerLeaders struct {
+	BrokerID int32 `json:"brokerId"`
+	Before   int   `json:"before"`
+	After    int   `json:"after"`
+}
+
+// LeaderElectionResult reports the outcome of a leader election.
+type LeaderElectionResult struct {
+	Type       string                    `json:"type"`
+	Partitions []PartitionElectionResult `json:"partitions"`
+	Leaders    []BrokerLeaders           `json:"leaders"`
+}
+
+// LeaderElectionService triggers leader elections with the ElectLeaders
+// API, which requires Kafka 2.4 or later.
+type LeaderElectionService struct {
+	kafkaService *Service
+}
+
+// NewLeaderElectionService creates a new LeaderElectionService.
+func NewLeaderElectionService(kafkaService *Service) *LeaderElectionService {
+	return &LeaderElectionService{
+		kafkaService: kafkaService,
+	}
+}
+
+// Elect runs a leader election and reports each partition's outcome and
+// new leader. For a whole topic or cluster, only the partitions that need an
+// election are included: those not led by their preferred replica, or
+// offline partitions for an unclean election.
+func (s *LeaderElectionService) Elect(ctx context.Context, clusterName string, req LeaderElectionRequest) (*LeaderElectionResult, error) {
+	var electionType sarama.ElectionType
+	switch req.Type {
+	case ElectionPreferred:
+		electionType = sarama.PreferredElection
+	case ElectionUnclean:
+		electionType = sarama.UncleanElection
+		if !req.ConfirmDataLoss {
+			return nil, fmt.Errorf("%w: an unclean election can lose committed records and must be confirmed with confirmDataLoss", ErrInvalidRequest)
+		}
+	default:
+		return nil, fmt.Errorf("%w: type must be %s or %s", ErrInvalidRequest, ElectionPreferred, ElectionUnclean)
+	}
+	if req.Topic != "" && len(req.Partitions) > 0 {
+		return nil, fmt.Errorf("%w: topic cannot be combined with partitions", ErrInvalidRequest)
+	}
+
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.kafkaService.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	// The client encodes ElectLeaders correctly from v2, sent to Kafka 2.4
+	// or later, which is also the first release with unclean elections.
+	if !version.IsAtLeast(sarama.V2_4_0_0) {
+		return nil, fmt.Errorf("leader election requires Kafka 2.4.0 or later, cluster %s runs %s", clusterName, version)
+	}
+
+	before, err := partitionLeaders(client, version)
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make(map[string][]int32)
+	if len(req.Partitions) > 0 {
+		seen := make(map[TopicPartition]bool, len(req.Partitions))
+		for _, p := range req.Partitions {
+			if seen[p] {
+				return nil, fmt.Errorf("%w: partition %d of topic %s is listed more than once", ErrInvalidRequest, p.Partition, p.Topic)
+			}
+			seen[p] = true
+			state, ok := before[p.Topic][p.Partition]
+			if !ok {
+				return nil, fmt.Errorf("%w: topic %s has no partition %d", ErrInvalidRequest, p.Topic, p.Partition)
+			}
+			if electionType == sarama.UncleanElection && state.leader >= 0 {
+				return nil, fmt.Errorf("%w: partition %d of topic %s has leader %d; unclean elections only apply to offline partitions", ErrInvalidRequest, p.Partition, p.Topic, state.leader)
+			}
+			partitions[p.Topic] = append(partitions[p.Topic], p.Partition)
+		}
+	} else {
+		if req.Topic != "" {
+			if _, ok := before[req.Topic]; !ok {
+				return nil, fmt.Errorf("%w: topic %s does not exist", ErrInvalidRequest, req.Topic)
+			}
+		}
+		for topic, states := range before {
+			if req.Topic != "" && topic != req.Topic {
+				continue
+			}
+			for partition, state := range states {
+				if state.needsElection(electionType) {
+					partitions[topic] = append(partitions[topic], partition)
+				}
+			}
+		}
+	}
+
+	result := &LeaderElectionResult{
+		Type:       req.Type,
+		Partitions: []PartitionElectionResult{},
+		Leaders:    []BrokerLeaders{},
+	}
+	after := before
+	if len(partitions) > 0 {
+		results, err := admin.ElectLeaders(electionType, partitions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to elect leaders: %w", err)
+		}
+		if after, err = partitionLeaders(client, version); err != nil {
+			return nil, fmt.Errorf("leaders were elected but could not be read back: %w", err)
+		}
+
+		for topic, ids := range partitions {
+			for _, partition := range ids {
+				outcome := PartitionElectionResult{
+					Topic:     topic,
+					Partition: partition,
+					Status:    ElectionElected,
+					Leader:    after[topic][partition].leader,
+				}
+				if res, ok := results[topic][partition]; ok && res != nil && !errors.Is(res.ErrorCode, sarama.ErrNoError) {
+					outcome.Status = ElectionFailed
+					if errors.Is(res.ErrorCode, sarama.ErrElectionNotNeeded) {
+						outcome.Status = ElectionNotNeeded
+					} else if res.ErrorMessage != nil && *res.ErrorMessage != "" {
+						outcome.Error = *res.ErrorMessage
+					} else {
+						outcome.Error = res.ErrorCode.Error()
+					}
+				}
+				result.Partitions = append(result.Partitions, outcome)
+			}
+		}
+		sort.Slice(result.Partitions, func(i, j int) bool {
+			a, b := result.Partitions[i], result.Partitions[j]
+			if a.Topic != b.Topic {
+				return a.Topic < b.Topic
+			}
+			return a.Partition < b.Partition
+		})
+	}
+
+	counts := make(map[int32]*BrokerLeaders)
+	for _, broker := range client.Brokers() {
+		counts[broker.ID()] = &BrokerLeaders{BrokerID: broker.ID()}
+	}
+	count := func(states map[string]map[int32]partitionLeader, add func(*BrokerLeaders)) {
+		for _, partitions := range states {
+			for _, state := range partitions {
+				if broker, ok := counts[state.leader]; ok {
+					add(broker)
+				}
+			}
+		}
+	}
+	count(before, func(b *BrokerLeaders) { b.Before++ })
+	count(after, func(b *BrokerLeaders) { b.After++ })
+	for _, broker := range counts {
+		result.Leaders = append(result.Leaders, *broker)
+	}
+	sort.Slice(result.Leaders, func(i, j int) bool {
+		return result.Leaders[i].BrokerID < result.Leaders[j].BrokerID
+	})
+	return result, nil
+}
+
+// partitionLeader is the leadership state of a partition.
+type partitionLeader struct {
+	leader    int32
+	preferred int32
+}
+
+// needsElection reports whether a whole-topic or cluster election should
+// include the partition.
+func (p partitionLeader) needsElection(electionType sarama.ElectionType) bool {
+	if electionType == sarama.UncleanElection {
+		return p.leader < 0
+	}
+	return p.preferred >= 0 && p.leader != p.preferred
+}
+
+// partitionLeaders returns the current and preferred leader of every
+// partition in the cluster, by topic and partition.
+func partitionLeaders(client sarama.Client, version sarama.KafkaVersion) (map[string]map[int32]partitionLeader, error) {
+	controller, err := client.Controller()
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := controller.GetMetadata(sarama.NewMetadataRequest(version, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster metadata: %w", err)
+	}
+
+	leaders := make(map[string]map[int32]partitionLeader, len(metadata.Topics))
+	for _, topic := range metadata.Topics {
+		if topic.Err == sarama.ErrUnknownTopicOrPartition {
+			continue
+		}
+		leaders[topic.Name] = make(map[int32]partitionLeader, len(topic.Partitions))
+		for _, p := range topic.Partitions {
+			state := partitionLeader{leader: p.Leader, preferred: -1}
+			if len(p.Replicas) > 0 {
+				state.preferred = p.Replicas[0]
+			}
+			leaders[topic.Name][p.ID] = state
+		}
+	}
+	return leaders, nil
+}
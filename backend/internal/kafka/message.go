@@ -33,16 +33,18 @@ func NewMessageService(kafkaService *Service) *MessageService {
 
 // GetMessages - Optimized version that doesn't wait unnecessarily
 func (s *MessageService) GetMessages(ctx context.Context, clusterName, topic string, limit int) ([]APIMessage, error) {
-	brokers, err := s.kafkaService.GetBrokers(clusterName)
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("could not get brokers for cluster %s: %w", clusterName, err)
+		return nil, err
 	}
-
-	client, err := sarama.NewClient(brokers, nil)
+	readerConfig, err := s.kafkaService.readerConfig(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	tuning, err := s.kafkaService.tuning(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("could not create client for cluster %s: %w", clusterName, err)
+		return nil, err
 	}
-	defer client.Close()
 
 	partitions, err := client.Partitions(topic)
 	if err != nil {
@@ -71,8 +73,8 @@ func (s *MessageService) GetMessages(ctx context.Context, clusterName, topic str
 		return []APIMessage{}, nil
 	}
 
-	// Much shorter timeout since we know exactly how many messages to read
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	// Short timeout since we know exactly how many messages to read
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(tuning.MessageTimeout))
 	defer cancel()
 
 	var allMessages []APIMessage
@@ -90,7 +92,7 @@ func (s *MessageService) GetMessages(ctx context.Context, clusterName, topic str
 			}
 
 			// Read all messages from this partition efficiently
-			messages := s.readPartitionMessages(ctx, brokers, topic, partitionID, info.oldest, info.newest)
+			messages := s.readPartitionMessages(ctx, readerConfig, time.Duration(tuning.FetchTimeout), topic, partitionID, info.oldest, info.newest)
 
 			mu.Lock()
 			allMessages = append(allMessages, messages...)
@@ -113,8 +115,9 @@ func (s *MessageService) GetMessages(ctx context.Context, clusterName, topic str
 	return allMessages, nil
 }
 
-// readPartitionMessages reads all messages from a partition efficiently
-func (s *MessageService) readPartitionMessages(ctx context.Context, brokers []string, topic string, partitionID int32, oldest, newest int64) []APIMessage {
+// readPartitionMessages reads all messages from a partition efficiently.
+// fetchTimeout bounds each read; a read that times out ends the partition.
+func (s *MessageService) readPartitionMessages(ctx context.Context, readerConfig kafka.ReaderConfig, fetchTimeout time.Duration, topic string, partitionID int32, oldest, newest int64) []APIMessage {
 	var messages []APIMessage
 
 	if newest <= oldest {
@@ -122,13 +125,9 @@ func (s *MessageService) readPartitionMessages(ctx context.Context, brokers []st
 	}
 
 	// Create reader with correct configuration
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:   brokers,
-		Topic:     topic,
-		Partition: int(partitionID),
-		MaxBytes:  1e6, // 1MB max
-		MinBytes:  1,   // Don't wait for batches
-	})
+	readerConfig.Topic = topic
+	readerConfig.Partition = int(partitionID)
+	r := kafka.NewReader(readerConfig)
 	defer r.Close()
 
 	// Set starting offset
@@ -147,8 +146,8 @@ func (s *MessageService) readPartitionMessages(ctx context.Context, brokers []st
 		default:
 		}
 
-		// Set a very short timeout for each read operation
-		readCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		// Set a short timeout for each read operation
+		readCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
 		m, err := r.ReadMessage(readCtx)
 		cancel()
 
@@ -177,24 +176,26 @@ func (s *MessageService) readPartitionMessages(ctx context.Context, brokers []st
 
 // GetLatestMessages - Alternative method for even faster latest message retrieval
 func (s *MessageService) GetLatestMessages(ctx context.Context, clusterName, topic string, limit int) ([]APIMessage, error) {
-	brokers, err := s.kafkaService.GetBrokers(clusterName)
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("could not get brokers for cluster %s: %w", clusterName, err)
+		return nil, err
 	}
-
-	client, err := sarama.NewClient(brokers, nil)
+	readerConfig, err := s.kafkaService.readerConfig(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("could not create client for cluster %s: %w", clusterName, err)
+		return nil, err
+	}
+	tuning, err := s.kafkaService.tuning(clusterName)
+	if err != nil {
+		return nil, err
 	}
-	defer client.Close()
 
 	partitions, err := client.Partitions(topic)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topic, err)
 	}
 
-	// Very short timeout for latest messages
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	// Short timeout for latest messages
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(tuning.LatestMessagesTimeout))
 	defer cancel()
 
 	var allMessages []APIMessage
@@ -227,7 +228,7 @@ func (s *MessageService) GetLatestMessages(ctx context.Context, clusterName, top
 				startOffset = oldest
 			}
 
-			messages := s.readPartitionMessages(ctx, brokers, topic, partitionID, startOffset, newest)
+			messages := s.readPartitionMessages(ctx, readerConfig, time.Duration(tuning.FetchTimeout), topic, partitionID, startOffset, newest)
 
 			mu.Lock()
 			allMessages = append(allMessages, messages...)
@@ -252,22 +253,12 @@ func (s *MessageService) GetLatestMessages(ctx context.Context, clusterName, top
 // ProduceMessage sends a message to a topic in a specific cluster, optionally to a specific partition.
 // If partition is -1, one will be chosen automatically.
 func (s *MessageService) ProduceMessage(ctx context.Context, clusterName, topic string, key, value []byte, partition int32) error {
-	brokers, err := s.kafkaService.GetBrokers(clusterName)
-	if err != nil {
-		return fmt.Errorf("could not get brokers for cluster %s: %w", clusterName, err)
-	}
-
-	config := sarama.NewConfig()
-	config.Producer.Return.Successes = true
-	// When a specific partition is set on the message, the producer will use it.
-	// When Partition is set to -1, it will use the default partitioner (e.g., round-robin).
-	// We do not need to set a manual partitioner.
-
-	producer, err := sarama.NewSyncProducer(brokers, config)
+	// The pooled producer uses the partition set on the message, or hashes
+	// the key when Partition is -1.
+	producer, err := s.kafkaService.GetProducer(clusterName)
 	if err != nil {
-		return fmt.Errorf("could not create sync producer: %w", err)
+		return err
 	}
-	defer producer.Close()
 
 	msg := &sarama.ProducerMessage{
 		Topic:     topic,
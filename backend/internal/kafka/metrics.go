@@ -83,17 +83,11 @@ func (s *MetricsService) GetConsumerGroupsLag(ctx context.Context, clusterName s
 		return nil, fmt.Errorf("could not get admin client for cluster %s: %w", clusterName, err)
 	}
 
-	brokers, err := s.kafkaService.GetBrokers(clusterName)
-	if err != nil {
-		return nil, fmt.Errorf("could not get brokers for cluster %s: %w", clusterName, err)
-	}
-
 	// A regular client is needed for fetching offsets
-	client, err := sarama.NewClient(brokers, nil)
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("could not create sarama client for cluster %s: %w", clusterName, err)
+		return nil, fmt.Errorf("could not get sarama client for cluster %s: %w", clusterName, err)
 	}
-	defer client.Close()
 
 	groups, err := admin.ListConsumerGroups()
 	if err != nil {
@@ -306,16 +300,10 @@ func (s *MetricsService) GetTopicMetrics(ctx context.Context, clusterName string
 		return nil, fmt.Errorf("could not get admin client for cluster %s: %w", clusterName, err)
 	}
 
-	brokers, err := s.kafkaService.GetBrokers(clusterName)
-	if err != nil {
-		return nil, fmt.Errorf("could not get brokers for cluster %s: %w", clusterName, err)
-	}
-
-	client, err := sarama.NewClient(brokers, nil)
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("could not create sarama client for cluster %s: %w", clusterName, err)
+		return nil, fmt.Errorf("could not get sarama client for cluster %s: %w", clusterName, err)
 	}
-	defer client.Close()
 
 	topics, err := admin.ListTopics()
 	if err != nil {
@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRequest marks errors caused by the request rather than the
+// cluster, so handlers can report them as validation errors.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// keySampleSize is the number of recent messages sampled to detect keyed data.
+const keySampleSize = 100
+
+// PartitionIncrease is a request to grow a topic to Count partitions.
+// Assignment optionally lists the replica broker IDs of each new partition.
+type PartitionIncrease struct {
+	Count        int32     `json:"count"`
+	Assignment   [][]int32 `json:"assignment"`
+	ValidateOnly bool      `json:"validateOnly"`
+}
+
+// PartitionIncreaseResult reports the outcome of a partition increase.
+type PartitionIncreaseResult struct {
+	Topic         string   `json:"topic"`
+	PreviousCount int32    `json:"previousCount"`
+	Count         int32    `json:"count"`
+	ValidateOnly  bool     `json:"validateOnly"`
+	Warnings      []string `json:"warnings"`
+}
+
+// IncreasePartitions grows a topic with CreatePartitions. Recent messages
+// are sampled first, and a warning is returned when they carry keys, since
+// the key-to-partition mapping changes with the partition count.
+func (s *TopicService) IncreasePartitions(ctx context.Context, clusterName, topicName string, req PartitionIncrease) (*PartitionIncreaseResult, error) {
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.kafkaService.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RefreshMetadata(topicName); err != nil {
+		return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topicName, err)
+	}
+	partitions, err := client.Partitions(topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topicName, err)
+	}
+	current := int32(len(partitions))
+
+	if req.Count <= current {
+		return nil, fmt.Errorf("%w: topic %s already has %d partitions, the new count must be higher", ErrInvalidRequest, topicName, current)
+	}
+	if len(req.Assignment) > 0 && int32(len(req.Assignment)) != req.Count-current {
+		return nil, fmt.Errorf("%w: assignment lists %d partitions but %d are being added", ErrInvalidRequest, len(req.Assignment), req.Count-current)
+	}
+
+	result := &PartitionIncreaseResult{
+		Topic:         topicName,
+		PreviousCount: current,
+		Count:         req.Count,
+		ValidateOnly:  req.ValidateOnly,
+		Warnings:      []string{},
+	}
+	if keyed, sampled := s.sampleKeyedMessages(ctx, clusterName, topicName); keyed > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"%d of the %d most recent messages have keys; adding partitions changes which partition a key maps to, so new messages for an existing key may not be ordered after older ones",
+			keyed, sampled))
+	}
+
+	var assignment [][]int32
+	if len(req.Assignment) > 0 {
+		assignment = req.Assignment
+	}
+	if err := admin.CreatePartitions(topicName, req.Count, assignment, req.ValidateOnly); err != nil {
+		return nil, fmt.Errorf("failed to create partitions for topic %s: %w", topicName, err)
+	}
+	return result, nil
+}
+
+// sampleKeyedMessages samples the most recent messages of a topic and returns
+// how many of them have a key, and how many were sampled. Sampling is best
+// effort; a topic that cannot be read counts as unkeyed.
+func (s *TopicService) sampleKeyedMessages(ctx context.Context, clusterName, topicName string) (int, int) {
+	messages := &MessageService{kafkaService: s.kafkaService}
+	sample, err := messages.GetLatestMessages(ctx, clusterName, topicName, keySampleSize)
+	if err != nil {
+		return 0, 0
+	}
+
+	keyed := 0
+	for _, message := range sample {
+		if message.Key != "" {
+			keyed++
+		}
+	}
+	return keyed, len(sample)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -47,3 +48,25 @@ func (p *Producer) ProduceMessage(topic, key, value string) error {
 func (p *Producer) Close() error {
 	return p.writer.Close()
 }
+
+// explicitPartitioner sends a message to ProducerMessage.Partition when it is
+// set (>= 0) and hashes the key otherwise, so a single pooled producer can
+// serve both kinds of requests.
+type explicitPartitioner struct {
+	hash sarama.Partitioner
+}
+
+func newExplicitPartitioner(topic string) sarama.Partitioner {
+	return &explicitPartitioner{hash: sarama.NewHashPartitioner(topic)}
+}
+
+func (p *explicitPartitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if message.Partition >= 0 {
+		return message.Partition, nil
+	}
+	return p.hash.Partition(message, numPartitions)
+}
+
+func (p *explicitPartitioner) RequiresConsistency() bool {
+	return true
+}
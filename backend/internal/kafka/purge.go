@@ -0,0 +1,188 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+)
+
+// RecordDeletion is a request to delete the records of a topic before a
+// point in each partition. Exactly one of Offsets, Timestamp and All is set:
+// Offsets maps partitions to the first offset to keep, Timestamp (Unix
+// milliseconds) keeps the records written at or after it, and All purges
+// everything up to the high watermark. Partitions restricts Timestamp and
+// All to some partitions; by default every partition is purged.
+type RecordDeletion struct {
+	Offsets    map[int32]int64 `json:"offsets"`
+	Timestamp  *int64          `json:"timestamp"`
+	All        bool            `json:"all"`
+	Partitions []int32         `json:"partitions"`
+	DryRun     bool            `json:"dryRun"`
+}
+
+// Validate checks that the request names exactly one deletion point and
+// each partition at most once.
+func (d RecordDeletion) Validate() error {
+	modes := 0
+	if len(d.Offsets) > 0 {
+		modes++
+	}
+	if d.Timestamp != nil {
+		modes++
+	}
+	if d.All {
+		modes++
+	}
+	if modes != 1 {
+		return fmt.Errorf("%w: exactly one of offsets, timestamp and all is required", ErrInvalidRequest)
+	}
+	if len(d.Offsets) > 0 && len(d.Partitions) > 0 {
+		return fmt.Errorf("%w: partitions cannot be combined with offsets, which already name the partitions", ErrInvalidRequest)
+	}
+	seen := make(map[int32]bool, len(d.Partitions))
+	for _, partition := range d.Partitions {
+		if seen[partition] {
+			return fmt.Errorf("%w: partition %d is listed more than once", ErrInvalidRequest, partition)
+		}
+		seen[partition] = true
+	}
+	for partition, offset := range d.Offsets {
+		if offset < 0 {
+			return fmt.Errorf("%w: offset for partition %d must not be negative", ErrInvalidRequest, partition)
+		}
+	}
+	if d.Timestamp != nil && *d.Timestamp < 0 {
+		return fmt.Errorf("%w: timestamp must not be negative", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// PartitionRecordDeletion reports the records deleted from one partition.
+// Records from LowWatermark up to, but not including, DeleteBefore are removed.
+type PartitionRecordDeletion struct {
+	Partition     int32 `json:"partition"`
+	LowWatermark  int64 `json:"lowWatermark"`
+	HighWatermark int64 `json:"highWatermark"`
+	DeleteBefore  int64 `json:"deleteBefore"`
+	Removed       int64 `json:"removed"`
+}
+
+// RecordDeletionResult reports the outcome of a record deletion.
+type RecordDeletionResult struct {
+	Topic      string                    `json:"topic"`
+	DryRun     bool                      `json:"dryRun"`
+	Partitions []PartitionRecordDeletion `json:"partitions"`
+	Removed    int64                     `json:"removed"`
+}
+
+// DeleteRecords truncates the partitions of a topic with DeleteRecords,
+// which requires Kafka 0.11 or later. With DryRun the deletion points are
+// resolved and reported without deleting anything.
+func (s *TopicService) DeleteRecords(ctx context.Context, clusterName, topicName string, req RecordDeletion) (*RecordDeletionResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.kafkaService.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if !version.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, fmt.Errorf("deleting records requires Kafka 0.11.0 or later, cluster %s runs %s", clusterName, version)
+	}
+
+	if err := client.RefreshMetadata(topicName); err != nil {
+		return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topicName, err)
+	}
+	all, err := client.Partitions(topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topicName, err)
+	}
+	known := make(map[int32]bool, len(all))
+	for _, partition := range all {
+		known[partition] = true
+	}
+
+	var partitions []int32
+	switch {
+	case len(req.Offsets) > 0:
+		for partition := range req.Offsets {
+			partitions = append(partitions, partition)
+		}
+	case len(req.Partitions) > 0:
+		partitions = req.Partitions
+	default:
+		partitions = all
+	}
+	for _, partition := range partitions {
+		if !known[partition] {
+			return nil, fmt.Errorf("%w: topic %s has no partition %d", ErrInvalidRequest, topicName, partition)
+		}
+	}
+
+	offsets, err := partitionWatermarks(client, topicName, partitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watermarks for topic %s: %w", topicName, err)
+	}
+
+	result := &RecordDeletionResult{
+		Topic:      topicName,
+		DryRun:     req.DryRun,
+		Partitions: make([]PartitionRecordDeletion, 0, len(partitions)),
+	}
+	deletions := make(map[int32]int64)
+	for _, partition := range partitions {
+		marks := offsets[partition]
+		before := marks.high
+		switch {
+		case len(req.Offsets) > 0:
+			before = req.Offsets[partition]
+			if before > marks.high {
+				return nil, fmt.Errorf("%w: offset %d for partition %d is beyond the high watermark %d", ErrInvalidRequest, before, partition, marks.high)
+			}
+		case req.Timestamp != nil:
+			// The broker returns the first offset written at or after the
+			// timestamp, or -1 when every record is older.
+			offset, err := client.GetOffset(topicName, partition, *req.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up the offset of partition %d at timestamp %d: %w", partition, *req.Timestamp, err)
+			}
+			if offset >= 0 {
+				before = offset
+			}
+		}
+
+		deletion := PartitionRecordDeletion{
+			Partition:     partition,
+			LowWatermark:  marks.low,
+			HighWatermark: marks.high,
+			DeleteBefore:  before,
+		}
+		if before > marks.low {
+			deletion.Removed = before - marks.low
+			deletions[partition] = before
+		}
+		result.Partitions = append(result.Partitions, deletion)
+		result.Removed += deletion.Removed
+	}
+	sort.Slice(result.Partitions, func(i, j int) bool {
+		return result.Partitions[i].Partition < result.Partitions[j].Partition
+	})
+
+	if req.DryRun || len(deletions) == 0 {
+		return result, nil
+	}
+	if err := admin.DeleteRecords(topicName, deletions); err != nil {
+		return nil, fmt.Errorf("failed to delete records of topic %s: %w", topicName, err)
+	}
+	return result, nil
+}
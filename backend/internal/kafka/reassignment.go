@@ -0,0 +1,653 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// Replication throttle configs. The rates are broker configs in bytes per
+// second; the replica lists are topic configs of partition:broker pairs.
+const (
+	leaderThrottleRate        = "leader.replication.throttled.rate"
+	followerThrottleRate      = "follower.replication.throttled.rate"
+	leaderThrottledReplicas   = "leader.replication.throttled.replicas"
+	followerThrottledReplicas = "follower.replication.throttled.replicas"
+)
+
+// Reassignment states reported after a submission.
+const (
+	ReassignmentInProgress = "in_progress"
+	ReassignmentCompleted  = "completed"
+	ReassignmentFailed     = "failed"
+)
+
+// PartitionReassignment moves a partition to the given replica brokers, the
+// first being the preferred leader.
+type PartitionReassignment struct {
+	Topic     string  `json:"topic"`
+	Partition int32   `json:"partition"`
+	Replicas  []int32 `json:"replicas"`
+}
+
+// ReassignmentRequest submits partition reassignments. Throttle, in bytes
+// per second, limits replication traffic on the brokers involved while the
+// replicas move; it stays in place until removed with RemoveThrottle.
+type ReassignmentRequest struct {
+	Partitions []PartitionReassignment `json:"partitions"`
+	Throttle   int64                   `json:"throttle"`
+}
+
+// PartitionReassignmentStatus is the state of a submitted partition reassignment.
+type PartitionReassignmentStatus struct {
+	Topic     string  `json:"topic"`
+	Partition int32   `json:"partition"`
+	Replicas  []int32 `json:"replicas"`
+	Status    string  `json:"status"`
+}
+
+// ReassignmentResult reports the outcome of a reassignment submission.
+type ReassignmentResult struct {
+	Partitions []PartitionReassignmentStatus `json:"partitions"`
+	Throttle   int64                         `json:"throttle"`
+	Warnings   []string                      `json:"warnings"`
+}
+
+// TopicPartition identifies a partition.
+type TopicPartition struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+}
+
+// ReplicaProgress is how far a new replica has caught up with the leader.
+type ReplicaProgress struct {
+	BrokerID  int32   `json:"brokerId"`
+	Size      int64   `json:"size"`
+	OffsetLag int64   `json:"offsetLag"`
+	InSync    bool    `json:"inSync"`
+	Percent   float64 `json:"percent"`
+}
+
+// ReassignmentProgress is an in-progress partition reassignment. Replicas
+// is the current replica set, which includes both the adding and removing
+// replicas until the move completes. Percent is the progress of the slowest
+// adding replica, estimated from its size on disk relative to the leader's.
+type ReassignmentProgress struct {
+	Topic            string            `json:"topic"`
+	Partition        int32             `json:"partition"`
+	Leader           int32             `json:"leader"`
+	Replicas         []int32           `json:"replicas"`
+	TargetReplicas   []int32           `json:"targetReplicas"`
+	AddingReplicas   []int32           `json:"addingReplicas"`
+	RemovingReplicas []int32           `json:"removingReplicas"`
+	LeaderSize       int64             `json:"leaderSize"`
+	Progress         []ReplicaProgress `json:"progress"`
+	Percent          float64           `json:"percent"`
+}
+
+// ReassignmentList lists the in-progress reassignments of a cluster.
+// Warnings reports progress details that could not be collected.
+type ReassignmentList struct {
+	Reassignments []ReassignmentProgress `json:"reassignments"`
+	Warnings      []string               `json:"warnings"`
+}
+
+// ReassignmentService moves partition replicas between brokers with the
+// AlterPartitionReassignments API, which requires Kafka 2.4 or later.
+type ReassignmentService struct {
+	kafkaService *Service
+}
+
+// NewReassignmentService creates a new ReassignmentService.
+func NewReassignmentService(kafkaService *Service) *ReassignmentService {
+	return &ReassignmentService{
+		kafkaService: kafkaService,
+	}
+}
+
+// clients returns the client and admin of a cluster once it is known to
+// support partition reassignment.
+func (s *ReassignmentService) clients(clusterName string) (sarama.Client, sarama.ClusterAdmin, error) {
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	admin, err := s.kafkaService.GetClient(clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !version.IsAtLeast(sarama.V2_4_0_0) {
+		return nil, nil, fmt.Errorf("partition reassignment requires Kafka 2.4.0 or later, cluster %s runs %s", clusterName, version)
+	}
+	return client, admin, nil
+}
+
+// Submit validates and starts partition reassignments. Each partition is
+// reported as in progress, completed when it already has the target
+// replicas, or failed when the controller did not accept it.
+func (s *ReassignmentService) Submit(ctx context.Context, clusterName string, req ReassignmentRequest) (*ReassignmentResult, error) {
+	if len(req.Partitions) == 0 {
+		return nil, fmt.Errorf("%w: at least one partition is required", ErrInvalidRequest)
+	}
+	if req.Throttle < 0 {
+		return nil, fmt.Errorf("%w: throttle must not be negative", ErrInvalidRequest)
+	}
+	client, admin, err := s.clients(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateReassignments(client, req.Partitions); err != nil {
+		return nil, err
+	}
+	topics := make([]string, 0, len(req.Partitions))
+	for _, p := range req.Partitions {
+		topics = append(topics, p.Topic)
+	}
+	if err := client.RefreshMetadata(topics...); err != nil {
+		return nil, fmt.Errorf("failed to refresh metadata: %w", err)
+	}
+
+	result := &ReassignmentResult{
+		Partitions: make([]PartitionReassignmentStatus, 0, len(req.Partitions)),
+		Throttle:   req.Throttle,
+		Warnings:   []string{},
+	}
+	var throttled []throttleChange
+	if req.Throttle > 0 {
+		if throttled, err = applyThrottle(client, admin, req.Partitions, req.Throttle); err != nil {
+			return nil, fmt.Errorf("failed to apply replication throttle: %w", err)
+		}
+		result.Warnings = append(result.Warnings, "the replication throttle stays in place until it is removed once the reassignment completes")
+	}
+
+	request := &sarama.AlterPartitionReassignmentsRequest{
+		TimeoutMs: int32(client.Config().Admin.Timeout.Milliseconds()),
+	}
+	for _, p := range req.Partitions {
+		request.AddBlock(p.Topic, p.Partition, p.Replicas)
+	}
+	if err := alterReassignments(client, request); err != nil {
+		if rollbackErr := restoreThrottle(admin, throttled); rollbackErr != nil {
+			return nil, fmt.Errorf("%w; the replication throttle could not be rolled back: %v", err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	// Per-partition errors are not exposed by the response, so the outcome
+	// is read back from the list of in-progress reassignments and the
+	// partitions' current replicas.
+	inProgress, err := listReassignments(client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reassignment was submitted but its state could not be read back: %w", err)
+	}
+	if err := client.RefreshMetadata(topics...); err != nil {
+		return nil, fmt.Errorf("reassignment was submitted but its state could not be read back: %w", err)
+	}
+	for _, p := range req.Partitions {
+		status := PartitionReassignmentStatus{
+			Topic:     p.Topic,
+			Partition: p.Partition,
+			Replicas:  p.Replicas,
+			Status:    ReassignmentFailed,
+		}
+		if _, ok := inProgress[p.Topic][p.Partition]; ok {
+			status.Status = ReassignmentInProgress
+		} else if current, err := client.Replicas(p.Topic, p.Partition); err == nil && sameReplicas(current, p.Replicas) {
+			status.Status = ReassignmentCompleted
+		}
+		result.Partitions = append(result.Partitions, status)
+	}
+	return result, nil
+}
+
+// List returns the in-progress reassignments with the progress of each new
+// replica. An empty topic lists the reassignments of every topic.
+func (s *ReassignmentService) List(ctx context.Context, clusterName, topic string) (*ReassignmentList, error) {
+	client, _, err := s.clients(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter map[string][]int32
+	if topic != "" {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get partitions for topic %s: %w", topic, err)
+		}
+		filter = map[string][]int32{topic: partitions}
+	}
+	statuses, err := listReassignments(client, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &ReassignmentList{
+		Reassignments: []ReassignmentProgress{},
+		Warnings:      []string{},
+	}
+	if len(statuses) == 0 {
+		return list, nil
+	}
+
+	topics := make([]string, 0, len(statuses))
+	replicas := make(map[string]map[int32][]int32, len(statuses))
+	for name, partitions := range statuses {
+		topics = append(topics, name)
+		replicas[name] = make(map[int32][]int32, len(partitions))
+		for partition, status := range partitions {
+			replicas[name][partition] = status.Replicas
+		}
+	}
+	if err := client.RefreshMetadata(topics...); err != nil {
+		return nil, fmt.Errorf("failed to refresh metadata: %w", err)
+	}
+	sizes, err := logDirSizes(client, version, replicas)
+	if err != nil {
+		list.Warnings = append(list.Warnings, "replica sizes are unavailable: "+err.Error())
+	}
+
+	for name, partitions := range statuses {
+		for partition, status := range partitions {
+			progress := ReassignmentProgress{
+				Topic:            name,
+				Partition:        partition,
+				Leader:           -1,
+				Replicas:         status.Replicas,
+				TargetReplicas:   withoutReplicas(status.Replicas, status.RemovingReplicas),
+				AddingReplicas:   nonNil(status.AddingReplicas),
+				RemovingReplicas: nonNil(status.RemovingReplicas),
+				Progress:         make([]ReplicaProgress, 0, len(status.AddingReplicas)),
+			}
+			if leader, err := client.Leader(name, partition); err == nil {
+				progress.Leader = leader.ID()
+			}
+			isr, _ := client.InSyncReplicas(name, partition)
+			replicaSizes := sizes[name][partition]
+			progress.LeaderSize = leaderSize(progress.Leader, replicaSizes)
+
+			progress.Percent = 100
+			for _, broker := range status.AddingReplicas {
+				replica := ReplicaProgress{BrokerID: broker, InSync: containsReplica(isr, broker)}
+				for _, size := range replicaSizes {
+					if size.BrokerID == broker && !size.IsFuture {
+						replica.Size = size.Size
+						replica.OffsetLag = size.OffsetLag
+					}
+				}
+				switch {
+				case replica.InSync:
+					replica.Percent = 100
+				case sizes == nil:
+					// Without sizes only an in-sync replica is known to be done.
+				case progress.LeaderSize == 0:
+					replica.Percent = 100
+				default:
+					replica.Percent = min(100, float64(replica.Size)*100/float64(progress.LeaderSize))
+				}
+				progress.Percent = min(progress.Percent, replica.Percent)
+				progress.Progress = append(progress.Progress, replica)
+			}
+			list.Reassignments = append(list.Reassignments, progress)
+		}
+	}
+	sort.Slice(list.Reassignments, func(i, j int) bool {
+		a, b := list.Reassignments[i], list.Reassignments[j]
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Partition < b.Partition
+	})
+	return list, nil
+}
+
+// Cancel cancels in-progress reassignments, reverting the partitions to
+// their original replicas. With all, and no partitions, every in-progress
+// reassignment is cancelled. It returns the partitions that were cancelled.
+func (s *ReassignmentService) Cancel(ctx context.Context, clusterName string, partitions []TopicPartition, all bool) ([]TopicPartition, error) {
+	if all && len(partitions) > 0 {
+		return nil, fmt.Errorf("%w: all cannot be combined with partitions", ErrInvalidRequest)
+	}
+	if !all && len(partitions) == 0 {
+		return nil, fmt.Errorf("%w: list the partitions to cancel, or set all to cancel every reassignment", ErrInvalidRequest)
+	}
+	client, _, err := s.clients(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	inProgress, err := listReassignments(client, nil)
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		for topic, statuses := range inProgress {
+			for partition := range statuses {
+				partitions = append(partitions, TopicPartition{Topic: topic, Partition: partition})
+			}
+		}
+	}
+	for _, p := range partitions {
+		if _, ok := inProgress[p.Topic][p.Partition]; !ok {
+			return nil, fmt.Errorf("%w: partition %d of topic %s has no reassignment in progress", ErrInvalidRequest, p.Partition, p.Topic)
+		}
+	}
+	if len(partitions) == 0 {
+		return []TopicPartition{}, nil
+	}
+
+	request := &sarama.AlterPartitionReassignmentsRequest{
+		TimeoutMs: int32(client.Config().Admin.Timeout.Milliseconds()),
+	}
+	for _, p := range partitions {
+		// A null replica list cancels the reassignment.
+		request.AddBlock(p.Topic, p.Partition, nil)
+	}
+	if err := alterReassignments(client, request); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+	return partitions, nil
+}
+
+// RemoveThrottle clears the replication throttle rates from every broker
+// and the throttled replica lists from the given topics.
+func (s *ReassignmentService) RemoveThrottle(ctx context.Context, clusterName string, topics []string) error {
+	client, admin, err := s.clients(clusterName)
+	if err != nil {
+		return err
+	}
+
+	remove := sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationDelete}
+	for _, broker := range client.Brokers() {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{
+			leaderThrottleRate:   remove,
+			followerThrottleRate: remove,
+		}
+		if err := admin.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(int(broker.ID())), entries, false); err != nil {
+			return fmt.Errorf("failed to remove throttle rates from broker %d: %w", broker.ID(), err)
+		}
+	}
+	for _, topic := range topics {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{
+			leaderThrottledReplicas:   remove,
+			followerThrottledReplicas: remove,
+		}
+		if err := admin.IncrementalAlterConfig(sarama.TopicResource, topic, entries, false); err != nil {
+			return fmt.Errorf("failed to remove throttled replicas from topic %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// validateReassignments checks that every partition exists, is listed
+// once, and is assigned to distinct, known brokers.
+func validateReassignments(client sarama.Client, partitions []PartitionReassignment) error {
+	brokers := make(map[int32]bool)
+	for _, broker := range client.Brokers() {
+		brokers[broker.ID()] = true
+	}
+
+	seen := make(map[TopicPartition]bool, len(partitions))
+	for _, p := range partitions {
+		key := TopicPartition{Topic: p.Topic, Partition: p.Partition}
+		if p.Topic == "" {
+			return fmt.Errorf("%w: every partition needs a topic", ErrInvalidRequest)
+		}
+		if seen[key] {
+			return fmt.Errorf("%w: partition %d of topic %s is listed more than once", ErrInvalidRequest, p.Partition, p.Topic)
+		}
+		seen[key] = true
+
+		existing, err := client.Partitions(p.Topic)
+		if err != nil {
+			return fmt.Errorf("%w: topic %s: %v", ErrInvalidRequest, p.Topic, err)
+		}
+		if !containsReplica(existing, p.Partition) {
+			return fmt.Errorf("%w: topic %s has no partition %d", ErrInvalidRequest, p.Topic, p.Partition)
+		}
+
+		if len(p.Replicas) == 0 {
+			return fmt.Errorf("%w: partition %d of topic %s needs at least one replica", ErrInvalidRequest, p.Partition, p.Topic)
+		}
+		replicas := make(map[int32]bool, len(p.Replicas))
+		for _, broker := range p.Replicas {
+			if !brokers[broker] {
+				return fmt.Errorf("%w: partition %d of topic %s is assigned to unknown broker %d", ErrInvalidRequest, p.Partition, p.Topic, broker)
+			}
+			if replicas[broker] {
+				return fmt.Errorf("%w: partition %d of topic %s lists broker %d more than once", ErrInvalidRequest, p.Partition, p.Topic, broker)
+			}
+			replicas[broker] = true
+		}
+	}
+	return nil
+}
+
+// throttleChange is the throttle config of a broker or topic before
+// applyThrottle changed it. A nil value was not set on the resource itself.
+type throttleChange struct {
+	resourceType sarama.ConfigResourceType
+	name         string
+	previous     map[string]*string
+}
+
+// applyThrottle sets the throttle rate on every broker that sends or
+// receives a replica, and appends the moving replicas to the throttled
+// replica lists of their topics: the current replicas on the leader side
+// and the new ones on the follower side. It returns the previous configs
+// for restoreThrottle, and restores them itself when it fails part way.
+func applyThrottle(client sarama.Client, admin sarama.ClusterAdmin, partitions []PartitionReassignment, rate int64) ([]throttleChange, error) {
+	brokers := make(map[int32]bool)
+	leaders := make(map[string][]string)
+	followers := make(map[string][]string)
+	for _, p := range partitions {
+		current, err := client.Replicas(p.Topic, p.Partition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicas of partition %d of topic %s: %w", p.Partition, p.Topic, err)
+		}
+		for _, broker := range current {
+			brokers[broker] = true
+			leaders[p.Topic] = append(leaders[p.Topic], fmt.Sprintf("%d:%d", p.Partition, broker))
+		}
+		for _, broker := range withoutReplicas(p.Replicas, current) {
+			brokers[broker] = true
+			followers[p.Topic] = append(followers[p.Topic], fmt.Sprintf("%d:%d", p.Partition, broker))
+		}
+	}
+
+	var changes []throttleChange
+	change := func(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry) error {
+		names := make([]string, 0, len(entries))
+		for config := range entries {
+			names = append(names, config)
+		}
+		previous, err := dynamicConfigValues(admin, sarama.ConfigResource{Type: resourceType, Name: name, ConfigNames: names})
+		if err != nil {
+			return err
+		}
+		if err := admin.IncrementalAlterConfig(resourceType, name, entries, false); err != nil {
+			return err
+		}
+		changes = append(changes, throttleChange{resourceType: resourceType, name: name, previous: previous})
+		return nil
+	}
+	fail := func(err error) ([]throttleChange, error) {
+		if rollbackErr := restoreThrottle(admin, changes); rollbackErr != nil {
+			return nil, fmt.Errorf("%w; the throttle already applied could not be rolled back: %v", err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	value := strconv.FormatInt(rate, 10)
+	for broker := range brokers {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{
+			leaderThrottleRate:   {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value},
+			followerThrottleRate: {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value},
+		}
+		if err := change(sarama.BrokerResource, strconv.Itoa(int(broker)), entries); err != nil {
+			return fail(fmt.Errorf("failed to set throttle rate on broker %d: %w", broker, err))
+		}
+	}
+	for topic, replicas := range leaders {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{}
+		leaderList := strings.Join(replicas, ",")
+		entries[leaderThrottledReplicas] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationAppend, Value: &leaderList}
+		if list, ok := followers[topic]; ok {
+			followerList := strings.Join(list, ",")
+			entries[followerThrottledReplicas] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationAppend, Value: &followerList}
+		}
+		if err := change(sarama.TopicResource, topic, entries); err != nil {
+			return fail(fmt.Errorf("failed to set throttled replicas on topic %s: %w", topic, err))
+		}
+	}
+	return changes, nil
+}
+
+// restoreThrottle puts back the configs recorded by applyThrottle, deleting
+// those that were not set before. It keeps going past failures and returns
+// them together.
+func restoreThrottle(admin sarama.ClusterAdmin, changes []throttleChange) error {
+	var errs []error
+	for _, change := range changes {
+		entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(change.previous))
+		for config, value := range change.previous {
+			if value == nil {
+				entries[config] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationDelete}
+			} else {
+				entries[config] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationSet, Value: value}
+			}
+		}
+		if err := admin.IncrementalAlterConfig(change.resourceType, change.name, entries, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", change.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dynamicConfigValues returns the requested configs of a resource, with nil
+// for those not set on the resource itself, which inherit their value.
+func dynamicConfigValues(admin sarama.ClusterAdmin, resource sarama.ConfigResource) (map[string]*string, error) {
+	entries, err := admin.DescribeConfig(resource)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]*string, len(resource.ConfigNames))
+	for _, config := range resource.ConfigNames {
+		values[config] = nil
+	}
+	for _, entry := range entries {
+		if _, requested := values[entry.Name]; !requested {
+			continue
+		}
+		if entry.Source == sarama.SourceTopic || entry.Source == sarama.SourceDynamicBroker {
+			value := entry.Value
+			values[entry.Name] = &value
+		}
+	}
+	return values, nil
+}
+
+// alterReassignments sends an AlterPartitionReassignments request to the controller.
+func alterReassignments(client sarama.Client, request *sarama.AlterPartitionReassignmentsRequest) error {
+	controller, err := client.Controller()
+	if err != nil {
+		return err
+	}
+	resp, err := controller.AlterPartitionReassignments(request)
+	if err != nil {
+		return fmt.Errorf("failed to alter partition reassignments: %w", err)
+	}
+	if resp.ErrorCode != sarama.ErrNoError {
+		if resp.ErrorMessage != nil {
+			return fmt.Errorf("failed to alter partition reassignments: %w: %s", resp.ErrorCode, *resp.ErrorMessage)
+		}
+		return fmt.Errorf("failed to alter partition reassignments: %w", resp.ErrorCode)
+	}
+	return nil
+}
+
+// listReassignments returns the in-progress reassignments by topic and
+// partition. A nil filter lists every reassignment.
+func listReassignments(client sarama.Client, filter map[string][]int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	controller, err := client.Controller()
+	if err != nil {
+		return nil, err
+	}
+	request := &sarama.ListPartitionReassignmentsRequest{
+		TimeoutMs: int32(client.Config().Admin.Timeout.Milliseconds()),
+	}
+	for topic, partitions := range filter {
+		request.AddBlock(topic, partitions)
+	}
+	resp, err := controller.ListPartitionReassignments(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partition reassignments: %w", err)
+	}
+	if resp.ErrorCode != sarama.ErrNoError {
+		return nil, fmt.Errorf("failed to list partition reassignments: %w", resp.ErrorCode)
+	}
+	if resp.TopicStatus == nil {
+		return map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus{}, nil
+	}
+	return resp.TopicStatus, nil
+}
+
+// withoutReplicas returns the brokers of replicas that are not in exclude.
+func withoutReplicas(replicas, exclude []int32) []int32 {
+	result := []int32{}
+	for _, broker := range replicas {
+		if !containsReplica(exclude, broker) {
+			result = append(result, broker)
+		}
+	}
+	return result
+}
+
+// sameReplicas reports whether two replica lists are equal, including order,
+// since the first replica is the preferred leader.
+func sameReplicas(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsReplica(replicas []int32, broker int32) bool {
+	for _, r := range replicas {
+		if r == broker {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNil(replicas []int32) []int32 {
+	if replicas == nil {
+		return []int32{}
+	}
+	return replicas
+}
@@ -0,0 +1,180 @@
+package kafka
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+)
+
+// ReloadSummary describes how the configuration file clusters changed when
+// the configuration was reloaded.
+type ReloadSummary struct {
+	Added     []string
+	Updated   []string
+	Removed   []string
+	Unchanged []string
+	// Errors lists the clusters that were applied but failed to connect.
+	// They are kept as disconnected for the health monitor to retry.
+	Errors []error
+}
+
+// Changed reports whether any cluster was added, updated or removed.
+func (r ReloadSummary) Changed() bool {
+	return len(r.Added)+len(r.Updated)+len(r.Removed) > 0
+}
+
+// String formats the summary for logging.
+func (r ReloadSummary) String() string {
+	if !r.Changed() {
+		return fmt.Sprintf("no cluster changes (%d unchanged)", len(r.Unchanged))
+	}
+	list := func(names []string) string {
+		if len(names) == 0 {
+			return "none"
+		}
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("added: %s; updated: %s; removed: %s; %d unchanged",
+		list(r.Added), list(r.Updated), list(r.Removed), len(r.Unchanged))
+}
+
+// SyncStaticClusters reconciles the configuration file clusters with a newly
+// loaded configuration: new clusters are added, changed ones are rebuilt and
+// clusters no longer in the file are removed. Replaced clients are closed
+// after a grace period so in-flight requests can finish. A runtime-added
+// cluster whose name now appears in the file is shadowed by the file
+// definition, as on startup, but stays in the cluster store.
+func (s *Service) SyncStaticClusters(clusters []config.ClusterConfig) ReloadSummary {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	desired := make(map[string]config.ClusterConfig, len(clusters))
+	for _, cluster := range clusters {
+		desired[cluster.Name] = cluster
+	}
+
+	s.mu.RLock()
+	current := make(map[string]config.ClusterConfig)
+	for name := range s.static {
+		if c, exists := s.clusters[name]; exists {
+			current[name] = c.config
+		}
+	}
+	s.mu.RUnlock()
+
+	var summary ReloadSummary
+	for name := range current {
+		if _, keep := desired[name]; !keep {
+			if err := s.removeStatic(name); err != nil {
+				summary.Errors = append(summary.Errors, err)
+			}
+			summary.Removed = append(summary.Removed, name)
+		}
+	}
+
+	for name, cluster := range desired {
+		previous, exists := current[name]
+		if exists && reflect.DeepEqual(previous, cluster) {
+			summary.Unchanged = append(summary.Unchanged, name)
+			continue
+		}
+
+		if err := s.replaceStatic(cluster); err != nil {
+			summary.Errors = append(summary.Errors, err)
+		}
+		if exists {
+			summary.Updated = append(summary.Updated, name)
+		} else {
+			summary.Added = append(summary.Added, name)
+		}
+	}
+
+	sort.Strings(summary.Added)
+	sort.Strings(summary.Updated)
+	sort.Strings(summary.Removed)
+	sort.Strings(summary.Unchanged)
+	return summary
+}
+
+// replaceStatic connects to a configuration file cluster and registers it in
+// place of any cluster with the same name. A cluster that fails to connect
+// is registered as disconnected.
+func (s *Service) replaceStatic(cluster config.ClusterConfig) error {
+	conn, err := openConnection(cluster)
+
+	s.mu.Lock()
+	previous := s.clusters[cluster.Name]
+	s.clusters[cluster.Name] = &managedCluster{
+		config: cluster,
+		conn:   conn,
+		health: newClusterHealth(err),
+	}
+	s.static[cluster.Name] = true
+	s.mu.Unlock()
+
+	if previous != nil && previous.conn != nil {
+		retire(previous.conn)
+	}
+	return err
+}
+
+// removeStatic unregisters a cluster that is no longer in the configuration
+// file. A runtime cluster of the same name that the file cluster shadowed is
+// registered again from the cluster store.
+func (s *Service) removeStatic(name string) error {
+	s.mu.Lock()
+	c := s.clusters[name]
+	delete(s.clusters, name)
+	delete(s.static, name)
+	s.mu.Unlock()
+
+	if c != nil && c.conn != nil {
+		retire(c.conn)
+	}
+	return s.restoreShadowed(name)
+}
+
+// restoreShadowed registers the stored runtime cluster called name, if any.
+// A cluster that fails to connect is registered as disconnected.
+func (s *Service) restoreShadowed(name string) error {
+	if s.store == nil {
+		return nil
+	}
+	stored, err := s.store.List()
+	if err != nil {
+		return fmt.Errorf("failed to read cluster store: %w", err)
+	}
+	for _, cluster := range stored {
+		if cluster.Name != name {
+			continue
+		}
+		conn, err := openConnection(cluster)
+
+		s.mu.Lock()
+		if _, exists := s.clusters[name]; exists || s.static[name] {
+			s.mu.Unlock()
+			if conn != nil {
+				conn.close()
+			}
+			return nil
+		}
+		s.clusters[name] = &managedCluster{
+			config: cluster,
+			conn:   conn,
+			health: newClusterHealth(err),
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// retire closes a replaced connection once requests that already hold it
+// have had time to finish.
+func retire(conn *connection) {
+	time.AfterFunc(drainTimeout, func() { conn.close() })
+}
@@ -0,0 +1,341 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// ReplicationFactorChange is a request to change the replication factor of
+// a topic. Without Plan a plan is generated; passing back the plan returned
+// by a dry run executes exactly what was reviewed.
+type ReplicationFactorChange struct {
+	ReplicationFactor int                     `json:"replicationFactor"`
+	Plan              []PartitionReassignment `json:"plan"`
+	Throttle          int64                   `json:"throttle"`
+	DryRun            bool                    `json:"dryRun"`
+}
+
+// PlannedPartition is the replica change of one partition in a plan.
+type PlannedPartition struct {
+	Partition int32   `json:"partition"`
+	Current   []int32 `json:"current"`
+	Target    []int32 `json:"target"`
+	Adding    []int32 `json:"adding"`
+	Removing  []int32 `json:"removing"`
+}
+
+// BrokerLoad is the number of replicas a broker hosts across the cluster
+// before and after a plan.
+type BrokerLoad struct {
+	BrokerID int32  `json:"brokerId"`
+	Rack     string `json:"rack,omitempty"`
+	Before   int    `json:"before"`
+	After    int    `json:"after"`
+}
+
+// ReplicationFactorPlan is the reviewed or executed plan of a replication
+// factor change. Plan is in the form accepted back by the request.
+// RackAware is set when every broker has a rack, in which case replicas of
+// a partition are spread over as many racks as possible.
+type ReplicationFactorPlan struct {
+	Topic                    string                  `json:"topic"`
+	CurrentReplicationFactor int                     `json:"currentReplicationFactor"`
+	ReplicationFactor        int                     `json:"replicationFactor"`
+	RackAware                bool                    `json:"rackAware"`
+	Partitions               []PlannedPartition      `json:"partitions"`
+	Plan                     []PartitionReassignment `json:"plan"`
+	BrokerLoad               []BrokerLoad            `json:"brokerLoad"`
+	DryRun                   bool                    `json:"dryRun"`
+	Reassignment             *ReassignmentResult     `json:"reassignment,omitempty"`
+	Warnings                 []string                `json:"warnings"`
+}
+
+// ChangeReplicationFactor plans a replication factor change of a topic and,
+// unless DryRun is set, executes it as a partition reassignment.
+func (s *ReassignmentService) ChangeReplicationFactor(ctx context.Context, clusterName, topicName string, req ReplicationFactorChange) (*ReplicationFactorPlan, error) {
+	if req.ReplicationFactor < 1 {
+		return nil, fmt.Errorf("%w: replicationFactor must be at least 1", ErrInvalidRequest)
+	}
+	client, admin, err := s.clients(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	controller, err := client.Controller()
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := controller.GetMetadata(sarama.NewMetadataRequest(version, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster metadata: %w", err)
+	}
+
+	var topic *sarama.TopicMetadata
+	load := make(map[int32]int)
+	for _, t := range metadata.Topics {
+		if t.Name == topicName {
+			topic = t
+		}
+		for _, p := range t.Partitions {
+			for _, broker := range p.Replicas {
+				load[broker]++
+			}
+		}
+	}
+	if topic == nil || topic.Err == sarama.ErrUnknownTopicOrPartition {
+		return nil, fmt.Errorf("%w: topic %s does not exist", ErrInvalidRequest, topicName)
+	}
+	if len(topic.Partitions) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", topicName)
+	}
+	if req.ReplicationFactor > len(metadata.Brokers) {
+		return nil, fmt.Errorf("%w: replicationFactor %d is higher than the %d brokers in the cluster", ErrInvalidRequest, req.ReplicationFactor, len(metadata.Brokers))
+	}
+
+	racks := make(map[int32]string, len(metadata.Brokers))
+	rackAware := true
+	for _, broker := range metadata.Brokers {
+		racks[broker.ID()] = broker.Rack()
+		if broker.Rack() == "" {
+			rackAware = false
+		}
+		if _, ok := load[broker.ID()]; !ok {
+			load[broker.ID()] = 0
+		}
+	}
+	before := make(map[int32]int, len(load))
+	for broker, count := range load {
+		before[broker] = count
+	}
+
+	sort.Slice(topic.Partitions, func(i, j int) bool {
+		return topic.Partitions[i].ID < topic.Partitions[j].ID
+	})
+	current := make(map[int32]*sarama.PartitionMetadata, len(topic.Partitions))
+	for _, p := range topic.Partitions {
+		current[p.ID] = p
+	}
+
+	planner := &replicaPlanner{racks: racks, load: load, rackAware: rackAware}
+	targets := make(map[int32][]int32, len(topic.Partitions))
+	if len(req.Plan) > 0 {
+		if err := validateReplicationPlan(topicName, req.ReplicationFactor, req.Plan, current); err != nil {
+			return nil, err
+		}
+		if err := validateReassignments(client, req.Plan); err != nil {
+			return nil, err
+		}
+		for _, p := range req.Plan {
+			for _, broker := range withoutReplicas(current[p.Partition].Replicas, p.Replicas) {
+				load[broker]--
+			}
+			for _, broker := range withoutReplicas(p.Replicas, current[p.Partition].Replicas) {
+				load[broker]++
+			}
+			targets[p.Partition] = p.Replicas
+		}
+	} else {
+		for _, p := range topic.Partitions {
+			targets[p.ID] = planner.plan(p, req.ReplicationFactor)
+		}
+	}
+
+	plan := &ReplicationFactorPlan{
+		Topic:                    topicName,
+		CurrentReplicationFactor: len(topic.Partitions[0].Replicas),
+		ReplicationFactor:        req.ReplicationFactor,
+		RackAware:                rackAware,
+		Partitions:               make([]PlannedPartition, 0, len(topic.Partitions)),
+		Plan:                     []PartitionReassignment{},
+		BrokerLoad:               make([]BrokerLoad, 0, len(load)),
+		DryRun:                   req.DryRun,
+		Warnings:                 []string{},
+	}
+	var reassignments []PartitionReassignment
+	for _, p := range topic.Partitions {
+		target := targets[p.ID]
+		plan.Partitions = append(plan.Partitions, PlannedPartition{
+			Partition: p.ID,
+			Current:   p.Replicas,
+			Target:    target,
+			Adding:    withoutReplicas(target, p.Replicas),
+			Removing:  withoutReplicas(p.Replicas, target),
+		})
+		reassignment := PartitionReassignment{Topic: topicName, Partition: p.ID, Replicas: target}
+		plan.Plan = append(plan.Plan, reassignment)
+		if !sameReplicas(p.Replicas, target) {
+			reassignments = append(reassignments, reassignment)
+		}
+	}
+	for broker, count := range load {
+		plan.BrokerLoad = append(plan.BrokerLoad, BrokerLoad{
+			BrokerID: broker,
+			Rack:     racks[broker],
+			Before:   before[broker],
+			After:    count,
+		})
+	}
+	sort.Slice(plan.BrokerLoad, func(i, j int) bool {
+		return plan.BrokerLoad[i].BrokerID < plan.BrokerLoad[j].BrokerID
+	})
+
+	if !rackAware {
+		plan.Warnings = append(plan.Warnings, "not every broker has a rack, so replicas are placed by load only")
+	}
+	if configs, err := describeConfigValues(admin, sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}); err == nil {
+		if minISR, err := strconv.Atoi(configs["min.insync.replicas"]); err == nil && minISR > req.ReplicationFactor {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf(
+				"min.insync.replicas is %d, higher than the new replication factor, so producers using acks=all will be rejected", minISR))
+		}
+	}
+	if len(reassignments) == 0 {
+		plan.Warnings = append(plan.Warnings, "every partition already has the target replicas")
+	}
+
+	if req.DryRun || len(reassignments) == 0 {
+		return plan, nil
+	}
+	plan.Reassignment, err = s.Submit(ctx, clusterName, ReassignmentRequest{Partitions: reassignments, Throttle: req.Throttle})
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// validateReplicationPlan checks that a reviewed plan covers every partition
+// of the topic once with the target replication factor. The brokers are
+// checked by validateReassignments.
+func validateReplicationPlan(topicName string, replicationFactor int, plan []PartitionReassignment, current map[int32]*sarama.PartitionMetadata) error {
+	seen := make(map[int32]bool, len(plan))
+	for _, p := range plan {
+		if p.Topic != topicName {
+			return fmt.Errorf("%w: plan entry for topic %s does not belong to topic %s", ErrInvalidRequest, p.Topic, topicName)
+		}
+		if _, ok := current[p.Partition]; !ok {
+			return fmt.Errorf("%w: topic %s has no partition %d", ErrInvalidRequest, topicName, p.Partition)
+		}
+		if seen[p.Partition] {
+			return fmt.Errorf("%w: partition %d is listed more than once", ErrInvalidRequest, p.Partition)
+		}
+		seen[p.Partition] = true
+		if len(p.Replicas) != replicationFactor {
+			return fmt.Errorf("%w: partition %d has %d replicas, expected %d", ErrInvalidRequest, p.Partition, len(p.Replicas), replicationFactor)
+		}
+	}
+	if len(seen) != len(current) {
+		return fmt.Errorf("%w: plan covers %d of the %d partitions", ErrInvalidRequest, len(seen), len(current))
+	}
+	return nil
+}
+
+// replicaPlanner chooses replicas for partitions. load is the number of
+// replicas per broker and is updated as replicas are placed, so later
+// partitions favour the brokers left least loaded.
+type replicaPlanner struct {
+	racks     map[int32]string
+	load      map[int32]int
+	rackAware bool
+}
+
+// plan returns the target replicas of a partition. Existing replicas keep
+// their order, so the preferred leader is unchanged.
+func (p *replicaPlanner) plan(partition *sarama.PartitionMetadata, replicationFactor int) []int32 {
+	target := append([]int32{}, partition.Replicas...)
+
+	for len(target) < replicationFactor {
+		broker, ok := p.pickAddition(target)
+		if !ok {
+			break
+		}
+		target = append(target, broker)
+		p.load[broker]++
+	}
+
+	for len(target) > replicationFactor {
+		index := p.pickRemoval(target, partition)
+		p.load[target[index]]--
+		target = append(target[:index], target[index+1:]...)
+	}
+	return target
+}
+
+// pickAddition returns the broker to add to replicas: one in the rack with
+// the fewest of the partition's replicas, then the least loaded, then the
+// lowest ID.
+func (p *replicaPlanner) pickAddition(replicas []int32) (int32, bool) {
+	perRack := p.rackCounts(replicas)
+	var best int32
+	found := false
+	for broker := range p.load {
+		if _, known := p.racks[broker]; !known || containsReplica(replicas, broker) {
+			continue
+		}
+		if !found || p.additionBefore(broker, best, perRack) {
+			best, found = broker, true
+		}
+	}
+	return best, found
+}
+
+func (p *replicaPlanner) additionBefore(a, b int32, perRack map[string]int) bool {
+	if p.rackAware && perRack[p.racks[a]] != perRack[p.racks[b]] {
+		return perRack[p.racks[a]] < perRack[p.racks[b]]
+	}
+	if p.load[a] != p.load[b] {
+		return p.load[a] < p.load[b]
+	}
+	return a < b
+}
+
+// pickRemoval returns the index of the replica to drop, which is called
+// with at least two replicas. The preferred leader is kept; out-of-sync
+// replicas go first, then those in the rack with the most of the
+// partition's replicas, then the most loaded.
+func (p *replicaPlanner) pickRemoval(replicas []int32, partition *sarama.PartitionMetadata) int {
+	perRack := p.rackCounts(replicas)
+	score := func(broker int32) []int {
+		inSync := 0
+		if containsReplica(partition.Isr, broker) {
+			inSync = 1
+		}
+		rackCount := 0
+		if p.rackAware {
+			rackCount = perRack[p.racks[broker]]
+		}
+		return []int{-inSync, rackCount, p.load[broker], int(broker)}
+	}
+
+	best := 1
+	for i := 2; i < len(replicas); i++ {
+		if greaterScore(score(replicas[i]), score(replicas[best])) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (p *replicaPlanner) rackCounts(replicas []int32) map[string]int {
+	counts := make(map[string]int)
+	for _, broker := range replicas {
+		counts[p.racks[broker]]++
+	}
+	return counts
+}
+
+// greaterScore compares two scores lexicographically.
+func greaterScore(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestReplicaPlannerPlan(t *testing.T) {
+	noRacks := map[int32]string{1: "", 2: "", 3: "", 4: ""}
+
+	tests := []struct {
+		name              string
+		racks             map[int32]string
+		rackAware         bool
+		load              map[int32]int
+		replicas          []int32
+		isr               []int32
+		replicationFactor int
+		want              []int32
+		wantLoad          map[int32]int
+	}{
+		{
+			name:              "unchanged",
+			racks:             noRacks,
+			load:              map[int32]int{1: 1, 2: 1, 3: 0, 4: 0},
+			replicas:          []int32{2, 1},
+			isr:               []int32{2, 1},
+			replicationFactor: 2,
+			want:              []int32{2, 1},
+			wantLoad:          map[int32]int{1: 1, 2: 1, 3: 0, 4: 0},
+		},
+		{
+			name:              "grow to the least loaded brokers, lowest ID first",
+			racks:             noRacks,
+			load:              map[int32]int{1: 3, 2: 1, 3: 1, 4: 0},
+			replicas:          []int32{1},
+			isr:               []int32{1},
+			replicationFactor: 3,
+			want:              []int32{1, 4, 2},
+			wantLoad:          map[int32]int{1: 3, 2: 2, 3: 1, 4: 1},
+		},
+		{
+			name:              "grow into empty racks before less loaded brokers",
+			racks:             map[int32]string{1: "a", 2: "a", 3: "b", 4: "b", 5: "c"},
+			rackAware:         true,
+			load:              map[int32]int{1: 5, 2: 1, 3: 2, 4: 0, 5: 9},
+			replicas:          []int32{1},
+			isr:               []int32{1},
+			replicationFactor: 3,
+			want:              []int32{1, 4, 5},
+			wantLoad:          map[int32]int{1: 5, 2: 1, 3: 2, 4: 1, 5: 10},
+		},
+		{
+			name:              "racks are ignored unless every broker has one",
+			racks:             map[int32]string{1: "a", 2: "a", 3: "b", 4: ""},
+			load:              map[int32]int{1: 0, 2: 0, 3: 1, 4: 1},
+			replicas:          []int32{1},
+			isr:               []int32{1},
+			replicationFactor: 2,
+			want:              []int32{1, 2},
+			wantLoad:          map[int32]int{1: 0, 2: 1, 3: 1, 4: 1},
+		},
+		{
+			name:              "more replicas than racks",
+			racks:             map[int32]string{1: "a", 2: "a", 3: "b"},
+			rackAware:         true,
+			load:              map[int32]int{1: 0, 2: 0, 3: 0},
+			replicas:          []int32{1},
+			isr:               []int32{1},
+			replicationFactor: 3,
+			want:              []int32{1, 3, 2},
+			wantLoad:          map[int32]int{1: 0, 2: 1, 3: 1},
+		},
+		{
+			name:              "grow stops when brokers run out",
+			racks:             map[int32]string{1: "", 2: ""},
+			load:              map[int32]int{1: 0, 2: 0},
+			replicas:          []int32{1},
+			isr:               []int32{1},
+			replicationFactor: 3,
+			want:              []int32{1, 2},
+			wantLoad:          map[int32]int{1: 0, 2: 1},
+		},
+		{
+			name:              "shrink drops out-of-sync replicas first",
+			racks:             noRacks,
+			load:              map[int32]int{1: 1, 2: 1, 3: 5, 4: 0},
+			replicas:          []int32{1, 2, 3},
+			isr:               []int32{1, 3},
+			replicationFactor: 2,
+			want:              []int32{1, 3},
+			wantLoad:          map[int32]int{1: 1, 2: 0, 3: 5, 4: 0},
+		},
+		{
+			name:              "shrink keeps the preferred leader even when out of sync",
+			racks:             noRacks,
+			load:              map[int32]int{1: 1, 2: 1, 3: 1, 4: 0},
+			replicas:          []int32{1, 2, 3},
+			isr:               []int32{2, 3},
+			replicationFactor: 1,
+			want:              []int32{1},
+			wantLoad:          map[int32]int{1: 1, 2: 0, 3: 0, 4: 0},
+		},
+		{
+			name:              "shrink the rack with the most replicas before the most loaded broker",
+			racks:             map[int32]string{1: "a", 2: "a", 3: "b"},
+			rackAware:         true,
+			load:              map[int32]int{1: 1, 2: 1, 3: 9},
+			replicas:          []int32{1, 2, 3},
+			isr:               []int32{1, 2, 3},
+			replicationFactor: 2,
+			want:              []int32{1, 3},
+			wantLoad:          map[int32]int{1: 1, 2: 0, 3: 9},
+		},
+		{
+			name:              "shrink the most loaded brokers, highest ID first",
+			racks:             noRacks,
+			load:              map[int32]int{1: 1, 2: 3, 3: 7, 4: 3},
+			replicas:          []int32{1, 2, 3, 4},
+			isr:               []int32{1, 2, 3, 4},
+			replicationFactor: 2,
+			want:              []int32{1, 2},
+			wantLoad:          map[int32]int{1: 1, 2: 3, 3: 6, 4: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner := &replicaPlanner{racks: tt.racks, load: tt.load, rackAware: tt.rackAware}
+			partition := &sarama.PartitionMetadata{Replicas: tt.replicas, Isr: tt.isr}
+			got := planner.plan(partition, tt.replicationFactor)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(planner.load, tt.wantLoad) {
+				t.Errorf("load after plan() = %v, want %v", planner.load, tt.wantLoad)
+			}
+			if !reflect.DeepEqual(partition.Replicas, tt.replicas) {
+				t.Errorf("plan() modified the current replicas to %v", partition.Replicas)
+			}
+		})
+	}
+}
+
+func TestReplicaPlannerSpreadsLoadAcrossPartitions(t *testing.T) {
+	planner := &replicaPlanner{
+		racks: map[int32]string{1: "", 2: "", 3: ""},
+		load:  map[int32]int{1: 3, 2: 0, 3: 0},
+	}
+	var got [][]int32
+	for i := 0; i < 3; i++ {
+		partition := &sarama.PartitionMetadata{ID: int32(i), Replicas: []int32{1}, Isr: []int32{1}}
+		got = append(got, planner.plan(partition, 2))
+	}
+	want := [][]int32{{1, 2}, {1, 3}, {1, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plans = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,279 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	xdgscram "github.com/xdg-go/scram"
+)
+
+// security holds the resolved TLS and SASL settings and the client tuning of
+// a cluster, shared by the sarama clients and the kafka-go readers and writers.
+type security struct {
+	tls    *tls.Config
+	sasl   config.SASLConfig
+	token  *oauthTokenProvider
+	tuning config.TuningConfig
+}
+
+// newSecurity loads the TLS material and resolves the SASL settings of a
+// cluster. The settings themselves are checked by ClusterConfig.Validate.
+func newSecurity(cluster config.ClusterConfig) (*security, error) {
+	tlsConfig, err := newTLSConfig(cluster.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS settings: %w", err)
+	}
+
+	sec := &security{tls: tlsConfig, sasl: cluster.SASL, tuning: cluster.Tuning.WithDefaults()}
+	if strings.EqualFold(cluster.SASL.Mechanism, sarama.SASLTypeOAuth) {
+		sec.token = &oauthTokenProvider{settings: cluster.SASL}
+	}
+	sec.sasl.Mechanism = strings.ToUpper(cluster.SASL.Mechanism)
+
+	return sec, nil
+}
+
+// applySarama configures TLS and SASL on a sarama config.
+func (sec *security) applySarama(cfg *sarama.Config) {
+	if sec.tls != nil {
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = sec.tls
+	}
+	if sec.sasl.Mechanism == "" {
+		return
+	}
+
+	cfg.Net.SASL.Enable = true
+	cfg.Net.SASL.Mechanism = sarama.SASLMechanism(sec.sasl.Mechanism)
+	cfg.Net.SASL.User = sec.sasl.Username
+	cfg.Net.SASL.Password = sec.sasl.Password
+	switch sec.sasl.Mechanism {
+	case sarama.SASLTypeSCRAMSHA256:
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: xdgscram.SHA256}
+		}
+	case sarama.SASLTypeSCRAMSHA512:
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: xdgscram.SHA512}
+		}
+	case sarama.SASLTypeOAuth:
+		cfg.Net.SASL.TokenProvider = sec.token
+	}
+}
+
+// mechanism returns the kafka-go SASL mechanism, or nil when SASL is disabled.
+func (sec *security) mechanism() (sasl.Mechanism, error) {
+	switch sec.sasl.Mechanism {
+	case sarama.SASLTypePlaintext:
+		return plain.Mechanism{Username: sec.sasl.Username, Password: sec.sasl.Password}, nil
+	case sarama.SASLTypeSCRAMSHA256:
+		return scram.Mechanism(scram.SHA256, sec.sasl.Username, sec.sasl.Password)
+	case sarama.SASLTypeSCRAMSHA512:
+		return scram.Mechanism(scram.SHA512, sec.sasl.Username, sec.sasl.Password)
+	case sarama.SASLTypeOAuth:
+		return oauthMechanism{provider: sec.token}, nil
+	}
+	return nil, nil
+}
+
+// dialer returns a kafka-go dialer for reader connections.
+func (sec *security) dialer() (*kafka.Dialer, error) {
+	mechanism, err := sec.mechanism()
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Dialer{
+		ClientID:      sec.tuning.ClientID,
+		Timeout:       time.Duration(sec.tuning.DialTimeout),
+		DualStack:     true,
+		TLS:           sec.tls,
+		SASLMechanism: mechanism,
+	}, nil
+}
+
+// newTLSConfig builds a tls.Config from the cluster settings.
+// It returns nil when TLS is disabled.
+func newTLSConfig(settings config.TLSConfig) (*tls.Config, error) {
+	if !settings.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+
+	caPEM, err := readPEM(settings.CACert, settings.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	if caPEM != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("CA bundle contains no valid certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certPEM, err := readPEM(settings.ClientCert, settings.ClientCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	keyPEM, err := readPEM(settings.ClientKey, settings.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client key: %w", err)
+	}
+	if (certPEM == nil) != (keyPEM == nil) {
+		return nil, errors.New("client certificate and client key must be provided together")
+	}
+	if certPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns the inline PEM if set, otherwise the contents of path.
+// It returns nil when neither is set.
+func readPEM(inline, path string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(path)
+}
+
+// scramClient implements sarama.SCRAMClient on top of xdg-go/scram.
+type scramClient struct {
+	hashGenerator xdgscram.HashGeneratorFcn
+	conversation  *xdgscram.ClientConversation
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.conversation = client.NewConversation()
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.conversation.Step(challenge)
+}
+
+func (c *scramClient) Done() bool {
+	return c.conversation.Done()
+}
+
+// oauthTokenProvider fetches OAUTHBEARER tokens from an OAuth 2.0 token
+// endpoint using the client credentials grant and caches them until shortly
+// before they expire. It implements sarama.AccessTokenProvider.
+type oauthTokenProvider struct {
+	settings config.SASLConfig
+	mu       sync.Mutex
+	token    string
+	expires  time.Time
+}
+
+// Token returns a cached token or fetches a new one.
+func (p *oauthTokenProvider) Token() (*sarama.AccessToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Now().Before(p.expires) {
+		return &sarama.AccessToken{Token: p.token}, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(p.settings.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.settings.Scopes, " "))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.settings.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.settings.ClientID), url.QueryEscape(p.settings.ClientSecret))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OAuth token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid token endpoint response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return nil, errors.New("token endpoint returned no access token")
+	}
+
+	lifetime := time.Duration(body.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = 5 * time.Minute
+	}
+	// Refresh a little early so a token never expires mid-handshake.
+	p.token = body.AccessToken
+	p.expires = time.Now().Add(lifetime * 9 / 10)
+
+	return &sarama.AccessToken{Token: p.token}, nil
+}
+
+// oauthMechanism implements the kafka-go SASL OAUTHBEARER mechanism.
+type oauthMechanism struct {
+	provider *oauthTokenProvider
+}
+
+func (m oauthMechanism) Name() string {
+	return sarama.SASLTypeOAuth
+}
+
+func (m oauthMechanism) Start(ctx context.Context) (sasl.StateMachine, []byte, error) {
+	token, err := m.provider.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	// RFC 7628 initial client response.
+	return m, []byte("n,,\x01auth=Bearer " + token.Token + "\x01\x01"), nil
+}
+
+func (m oauthMechanism) Next(ctx context.Context, challenge []byte) (bool, []byte, error) {
+	if len(challenge) > 0 {
+		return false, nil, fmt.Errorf("OAUTHBEARER authentication failed: %s", challenge)
+	}
+	return true, nil, nil
+}
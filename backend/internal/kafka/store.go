@@ -0,0 +1,140 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+)
+
+// ClusterStore persists clusters added at runtime so they survive restarts.
+type ClusterStore interface {
+	// List returns all stored clusters.
+	List() ([]config.ClusterConfig, error)
+	// Save creates or replaces the cluster with the same name.
+	Save(cluster config.ClusterConfig) error
+	// Delete removes a cluster. Deleting an unknown cluster is not an error.
+	Delete(name string) error
+}
+
+// FileClusterStore is a ClusterStore backed by a local JSON file.
+type FileClusterStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileClusterStore creates a store that reads and writes the JSON file at path.
+// The file is created on the first write.
+func NewFileClusterStore(path string) *FileClusterStore {
+	return &FileClusterStore{path: path}
+}
+
+// List returns all clusters in the file, sorted by name.
+func (f *FileClusterStore) List() ([]config.ClusterConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	clusters, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]config.ClusterConfig, 0, len(clusters))
+	for _, cluster := range clusters {
+		list = append(list, cluster)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// Save writes the cluster to the file, replacing any cluster with the same name.
+func (f *FileClusterStore) Save(cluster config.ClusterConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	clusters, err := f.read()
+	if err != nil {
+		return err
+	}
+	clusters[cluster.Name] = cluster
+	return f.write(clusters)
+}
+
+// Delete removes the cluster from the file.
+func (f *FileClusterStore) Delete(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	clusters, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, exists := clusters[name]; !exists {
+		return nil
+	}
+	delete(clusters, name)
+	return f.write(clusters)
+}
+
+func (f *FileClusterStore) read() (map[string]config.ClusterConfig, error) {
+	clusters := make(map[string]config.ClusterConfig)
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return clusters, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []config.ClusterConfig
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid cluster store %s: %w", f.path, err)
+	}
+	for _, cluster := range list {
+		clusters[cluster.Name] = cluster
+	}
+	return clusters, nil
+}
+
+// write replaces the file atomically so a crash never leaves it half written.
+func (f *FileClusterStore) write(clusters map[string]config.ClusterConfig) error {
+	list := make([]config.ClusterConfig, 0, len(clusters))
+	for _, cluster := range clusters {
+		list = append(list, cluster)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -8,27 +8,48 @@ import (
 )
 
 // APIPartitionMetadata defines the structure for partition details in the API response.
+// MessageCount is the difference between the watermarks, so it includes
+// compacted-away offsets and transaction markers. Size is the leader
+// replica's size on disk; LeaderEpoch is -1 on clusters older than Kafka 2.1.
 type APIPartitionMetadata struct {
-	ID              int32   `json:"id"`
-	Leader          int32   `json:"leader"`
-	Replicas        []int32 `json:"replicas"`
-	Isr             []int32 `json:"isr"`
-	OfflineReplicas []int32 `json:"offlineReplicas"`
+	ID              int32            `json:"id"`
+	Leader          int32            `json:"leader"`
+	LeaderEpoch     int32            `json:"leaderEpoch"`
+	Replicas        []int32          `json:"replicas"`
+	Isr             []int32          `json:"isr"`
+	OfflineReplicas []int32          `json:"offlineReplicas"`
+	LowWatermark    int64            `json:"lowWatermark"`
+	HighWatermark   int64            `json:"highWatermark"`
+	MessageCount    int64            `json:"messageCount"`
+	Size            int64            `json:"size"`
+	ReplicaSizes    []APIReplicaSize `json:"replicaSizes"`
 }
 
 // APITopicDetails defines the structure for the detailed topic view in the API response.
+// Configs holds the plain values; ConfigEntries adds where each value comes
+// from and whether it was customised. Size totals the leader replicas and
+// ReplicatedSize every replica. Warnings reports statistics that could not be
+// collected, such as replica sizes when the broker denies DescribeLogDirs.
 type APITopicDetails struct {
 	Name              string                 `json:"name"`
 	Partitions        []APIPartitionMetadata `json:"partitions"`
 	Configs           map[string]string      `json:"configs"`
+	ConfigEntries     []APIConfigEntry       `json:"configEntries"`
 	ReplicationFactor int                    `json:"replicationFactor"`
+	MessageCount      int64                  `json:"messageCount"`
+	Size              int64                  `json:"size"`
+	ReplicatedSize    int64                  `json:"replicatedSize"`
+	Warnings          []string               `json:"warnings"`
 }
 
-// APITopicSummary defines the structure for topic list items.
+// APITopicSummary defines the structure for topic list items. Size is the
+// total of the leader replicas on disk.
 type APITopicSummary struct {
 	Name              string `json:"name"`
 	PartitionCount    int    `json:"partitionCount"`
 	ReplicationFactor int    `json:"replicationFactor"`
+	Size              int64  `json:"size"`
+	Internal          bool   `json:"internal"`
 }
 
 // TopicService handles topic-related operations.
@@ -43,100 +64,121 @@ func NewTopicService(kafkaService *Service) *TopicService {
 	}
 }
 
-// GetTopics returns a list of all topics for a specific cluster with summary details.
-func (s *TopicService) GetTopics(ctx context.Context, clusterName string) ([]APITopicSummary, error) {
+// GetTopicDetails retrieves detailed information about a specific topic.
+func (s *TopicService) GetTopicDetails(ctx context.Context, clusterName string, topicName string) (*APITopicDetails, error) {
 	admin, err := s.kafkaService.GetClient(clusterName)
 	if err != nil {
 		return nil, err
 	}
 
-	topics, err := admin.ListTopics()
+	metadata, err := admin.DescribeTopics([]string{topicName})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list topics for cluster %s: %w", clusterName, err)
+		return nil, fmt.Errorf("failed to describe topic %s: %w", topicName, err)
 	}
 
-	topicNames := make([]string, 0, len(topics))
-	for name := range topics {
-		topicNames = append(topicNames, name)
+	if len(metadata) == 0 || metadata[0] == nil {
+		return nil, fmt.Errorf("topic not found: %s", topicName)
 	}
 
-	if len(topicNames) == 0 {
-		return []APITopicSummary{}, nil
+	topic := metadata[0]
+	if topic.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("error describing topic %s: %w", topicName, topic.Err)
 	}
 
-	metadata, err := admin.DescribeTopics(topicNames)
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to describe topics: %w", err)
-	}
-
-	summaries := make([]APITopicSummary, len(metadata))
-	for i, topic := range metadata {
-		summaries[i] = APITopicSummary{
-			Name:              topic.Name,
-			PartitionCount:    len(topic.Partitions),
-			ReplicationFactor: len(topic.Partitions[0].Replicas), // Assuming RF is consistent
-		}
+		return nil, err
 	}
-
-	return summaries, nil
-}
-
-// GetTopicDetails retrieves detailed information about a specific topic.
-func (s *TopicService) GetTopicDetails(ctx context.Context, clusterName string, topicName string) (*APITopicDetails, error) {
-	admin, err := s.kafkaService.GetClient(clusterName)
+	version, err := s.kafkaService.KafkaVersion(clusterName)
 	if err != nil {
 		return nil, err
 	}
 
-	metadata, err := admin.DescribeTopics([]string{topicName})
-	if err != nil {
-		return nil, fmt.Errorf("failed to describe topic %s: %w", topicName, err)
+	partitionIDs := make([]int32, len(topic.Partitions))
+	replicas := make(map[int32][]int32, len(topic.Partitions))
+	for i, p := range topic.Partitions {
+		partitionIDs[i] = p.ID
+		replicas[p.ID] = p.Replicas
 	}
-
-	if len(metadata) == 0 || metadata[0] == nil {
-		return nil, fmt.Errorf("topic not found: %s", topicName)
+	offsets, err := partitionWatermarks(client, topicName, partitionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watermarks for topic %s: %w", topicName, err)
 	}
 
-	topic := metadata[0]
-	if topic.Err != sarama.ErrNoError {
-		return nil, fmt.Errorf("error describing topic %s: %w", topicName, topic.Err)
+	warnings := []string{}
+	sizes, err := replicaLogSizes(client, version, topicName, replicas)
+	if err != nil {
+		warnings = append(warnings, "replica sizes are unavailable: "+err.Error())
 	}
 
 	// Manually construct the response with the correct field names
-	partitions := make([]APIPartitionMetadata, len(topic.Partitions))
+	details := &APITopicDetails{
+		Name:              topicName,
+		Partitions:        make([]APIPartitionMetadata, len(topic.Partitions)),
+		ReplicationFactor: len(topic.Partitions[0].Replicas),
+		Warnings:          warnings,
+	}
 	for i, p := range topic.Partitions {
-		partitions[i] = APIPartitionMetadata{
+		partition := APIPartitionMetadata{
 			ID:              p.ID,
 			Leader:          p.Leader,
+			LeaderEpoch:     -1,
 			Replicas:        p.Replicas,
 			Isr:             p.Isr,
 			OfflineReplicas: p.OfflineReplicas,
+			LowWatermark:    offsets[p.ID].low,
+			HighWatermark:   offsets[p.ID].high,
+			MessageCount:    offsets[p.ID].high - offsets[p.ID].low,
+			ReplicaSizes:    []APIReplicaSize{},
+		}
+		// The leader epoch is part of the metadata response since Kafka 2.1.
+		if version.IsAtLeast(sarama.V2_1_0_0) {
+			partition.LeaderEpoch = p.LeaderEpoch
+		}
+		if replicaSizes, ok := sizes[p.ID]; ok {
+			partition.ReplicaSizes = replicaSizes
 		}
+		partition.Size = leaderSize(p.Leader, partition.ReplicaSizes)
+		for _, replica := range partition.ReplicaSizes {
+			if !replica.IsFuture {
+				details.ReplicatedSize += replica.Size
+			}
+		}
+
+		details.Partitions[i] = partition
+		details.MessageCount += partition.MessageCount
+		details.Size += partition.Size
 	}
 
-	// Fetch topic configs
-	configs := make(map[string]string)
-	resource := sarama.ConfigResource{
-		Type: sarama.TopicResource,
-		Name: topicName,
+	// Fetch topic configs with their sources and synonyms
+	details.Configs = make(map[string]string)
+	details.ConfigEntries = []APIConfigEntry{}
+	entries, err := describeTopicConfigEntries(client, version, topicName)
+	if err != nil {
+		details.Warnings = append(details.Warnings, "topic configs are unavailable: "+err.Error())
 	}
-	configEntries, err := admin.DescribeConfig(resource)
-	if err == nil {
-		for _, entry := range configEntries {
-			configs[entry.Name] = entry.Value
-		}
+	details.ConfigEntries = append(details.ConfigEntries, entries...)
+	for _, entry := range entries {
+		details.Configs[entry.Name] = entry.Value
 	}
 
-	return &APITopicDetails{
-		Name:              topicName,
-		Partitions:        partitions,
-		Configs:           configs,
-		ReplicationFactor: len(topic.Partitions[0].Replicas),
-	}, nil
+	return details, nil
+}
+
+// CreateTopicOptions holds the optional settings of a new topic.
+type CreateTopicOptions struct {
+	// Configs are the initial topic config overrides, such as retention.ms.
+	Configs map[string]string
+	// ReplicaAssignment lists the replica broker IDs of each partition, the
+	// first being the preferred leader. When set, it replaces the partition
+	// count and replication factor.
+	ReplicaAssignment [][]int32
+	// ValidateOnly asks the broker to check the request without creating the topic.
+	ValidateOnly bool
 }
 
 // CreateTopic creates a new topic in a specific cluster.
-func (s *TopicService) CreateTopic(ctx context.Context, clusterName string, topicName string, numPartitions int32, replicationFactor int16) error {
+func (s *TopicService) CreateTopic(ctx context.Context, clusterName string, topicName string, numPartitions int32, replicationFactor int16, opts CreateTopicOptions) error {
 	admin, err := s.kafkaService.GetClient(clusterName)
 	if err != nil {
 		return err
@@ -146,8 +188,23 @@ func (s *TopicService) CreateTopic(ctx context.Context, clusterName string, topi
 		NumPartitions:     numPartitions,
 		ReplicationFactor: replicationFactor,
 	}
+	if len(opts.ReplicaAssignment) > 0 {
+		// The broker requires -1 for both when replicas are assigned explicitly.
+		topicDetail.NumPartitions = -1
+		topicDetail.ReplicationFactor = -1
+		topicDetail.ReplicaAssignment = make(map[int32][]int32, len(opts.ReplicaAssignment))
+		for partition, replicas := range opts.ReplicaAssignment {
+			topicDetail.ReplicaAssignment[int32(partition)] = replicas
+		}
+	}
+	if len(opts.Configs) > 0 {
+		topicDetail.ConfigEntries = make(map[string]*string, len(opts.Configs))
+		for name, value := range opts.Configs {
+			topicDetail.ConfigEntries[name] = &value
+		}
+	}
 
-	return admin.CreateTopic(topicName, topicDetail, false)
+	return admin.CreateTopic(topicName, topicDetail, opts.ValidateOnly)
 }
 
 // DeleteTopic deletes a topic from a specific cluster.
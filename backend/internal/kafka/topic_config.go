@@ -0,0 +1,369 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// configKind is the value type of a topic config.
+type configKind int
+
+const (
+	kindString configKind = iota
+	kindInt
+	kindLong
+	kindDouble
+	kindBoolean
+	kindList
+)
+
+// topicConfigSpec describes a topic config key the API accepts. values, if
+// set, lists the allowed values, or for lists the allowed items.
+type topicConfigSpec struct {
+	kind   configKind
+	values []string
+	doc    string
+}
+
+// topicConfigSpecs lists the topic-level configs of Apache Kafka 3.x.
+var topicConfigSpecs = map[string]topicConfigSpec{
+	"cleanup.policy":                          {kind: kindList, values: []string{"delete", "compact"}, doc: "Whether old segments are deleted, compacted by key, or both."},
+	"compression.type":                        {kind: kindString, values: []string{"uncompressed", "zstd", "lz4", "snappy", "gzip", "producer"}, doc: "Compression codec for the topic's data; producer keeps the codec set by the producer."},
+	"compression.gzip.level":                  {kind: kindInt, doc: "Compression level used when compression.type is gzip."},
+	"compression.lz4.level":                   {kind: kindInt, doc: "Compression level used when compression.type is lz4."},
+	"compression.zstd.level":                  {kind: kindInt, doc: "Compression level used when compression.type is zstd."},
+	"delete.retention.ms":                     {kind: kindLong, doc: "How long delete tombstones are kept for compacted topics."},
+	"file.delete.delay.ms":                    {kind: kindLong, doc: "Time to wait before deleting a file from the filesystem."},
+	"flush.messages":                          {kind: kindLong, doc: "Number of messages written before the log is forced to disk."},
+	"flush.ms":                                {kind: kindLong, doc: "Time after which the log is forced to disk."},
+	"follower.replication.throttled.replicas": {kind: kindList, doc: "Replicas whose log replication is throttled on the follower side."},
+	"index.interval.bytes":                    {kind: kindInt, doc: "How often an entry is added to the offset index."},
+	"leader.replication.throttled.replicas":   {kind: kindList, doc: "Replicas whose log replication is throttled on the leader side."},
+	"local.retention.bytes":                   {kind: kindLong, doc: "Maximum size of local log segments before they are eligible for deletion when tiered storage is enabled."},
+	"local.retention.ms":                      {kind: kindLong, doc: "Time local log segments are kept before deletion when tiered storage is enabled."},
+	"max.compaction.lag.ms":                   {kind: kindLong, doc: "Maximum time a message remains ineligible for compaction."},
+	"max.message.bytes":                       {kind: kindInt, doc: "Largest record batch size allowed."},
+	"message.downconversion.enable":           {kind: kindBoolean, doc: "Whether messages are down-converted for older consumers."},
+	"message.format.version":                  {kind: kindString, doc: "Message format version used to append messages (deprecated)."},
+	"message.timestamp.after.max.ms":          {kind: kindLong, doc: "Maximum allowed amount a message timestamp may be ahead of the broker time."},
+	"message.timestamp.before.max.ms":         {kind: kindLong, doc: "Maximum allowed amount a message timestamp may be behind the broker time."},
+	"message.timestamp.difference.max.ms":     {kind: kindLong, doc: "Maximum difference allowed between a message timestamp and the broker time (deprecated)."},
+	"message.timestamp.type":                  {kind: kindString, values: []string{"CreateTime", "LogAppendTime"}, doc: "Whether message timestamps are set by the producer or by the broker on append."},
+	"min.cleanable.dirty.ratio":               {kind: kindDouble, doc: "Ratio of uncompacted log to total log above which compaction runs."},
+	"min.compaction.lag.ms":                   {kind: kindLong, doc: "Minimum time a message remains uncompacted."},
+	"min.insync.replicas":                     {kind: kindInt, doc: "Minimum number of in-sync replicas required to acknowledge a write with acks=all."},
+	"preallocate":                             {kind: kindBoolean, doc: "Whether the segment file is preallocated on disk."},
+	"remote.storage.enable":                   {kind: kindBoolean, doc: "Whether tiered storage is enabled for the topic."},
+	"retention.bytes":                         {kind: kindLong, doc: "Maximum size a partition can grow to before old segments are discarded; -1 means no limit."},
+	"retention.ms":                            {kind: kindLong, doc: "How long messages are kept before old segments are discarded; -1 means no limit."},
+	"segment.bytes":                           {kind: kindInt, doc: "Size of a single log segment file."},
+	"segment.index.bytes":                     {kind: kindInt, doc: "Size of the index that maps offsets to file positions."},
+	"segment.jitter.ms":                       {kind: kindLong, doc: "Maximum random jitter subtracted from segment.ms to avoid rolling all segments at once."},
+	"segment.ms":                              {kind: kindLong, doc: "Time after which a segment is rolled even if it is not full."},
+	"unclean.leader.election.enable":          {kind: kindBoolean, doc: "Whether out-of-sync replicas may become leader, at the risk of losing data."},
+}
+
+// ValidateTopicConfig checks that name is a known topic config and that
+// value has the right type. The broker still has the final say on ranges.
+func ValidateTopicConfig(name, value string) error {
+	spec, known := topicConfigSpecs[name]
+	if !known {
+		return fmt.Errorf("unknown topic config %q", name)
+	}
+
+	var err error
+	switch spec.kind {
+	case kindInt:
+		_, err = strconv.ParseInt(value, 10, 32)
+	case kindLong:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case kindDouble:
+		_, err = strconv.ParseFloat(value, 64)
+	case kindBoolean:
+		_, err = strconv.ParseBool(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %q is not a valid %s", name, value, spec.kind)
+	}
+
+	if len(spec.values) == 0 {
+		return nil
+	}
+	items := []string{value}
+	if spec.kind == kindList {
+		items = strings.Split(value, ",")
+	}
+	for _, item := range items {
+		if !slices.Contains(spec.values, strings.TrimSpace(item)) {
+			return fmt.Errorf("%s: %q is not one of %s", name, item, strings.Join(spec.values, ", "))
+		}
+	}
+	return nil
+}
+
+func (k configKind) String() string {
+	switch k {
+	case kindInt:
+		return "int"
+	case kindLong:
+		return "long"
+	case kindDouble:
+		return "double"
+	case kindBoolean:
+		return "boolean"
+	case kindList:
+		return "list"
+	default:
+		return "string"
+	}
+}
+
+// TopicConfigUpdate is an incremental change to a topic's configuration.
+// Keys in Set are overridden; keys in Delete revert to the broker default.
+type TopicConfigUpdate struct {
+	Set          map[string]string `json:"set"`
+	Delete       []string          `json:"delete"`
+	ValidateOnly bool              `json:"validateOnly"`
+}
+
+// Validate checks every key and value of the update.
+func (u TopicConfigUpdate) Validate() error {
+	if len(u.Set) == 0 && len(u.Delete) == 0 {
+		return errors.New("at least one config to set or delete is required")
+	}
+
+	var errs []error
+	for name, value := range u.Set {
+		if err := ValidateTopicConfig(name, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range u.Delete {
+		if _, known := topicConfigSpecs[name]; !known {
+			errs = append(errs, fmt.Errorf("unknown topic config %q", name))
+		}
+		if _, set := u.Set[name]; set {
+			errs = append(errs, fmt.Errorf("%s: cannot both set and delete a config", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TopicConfigChange is the before and after value of one changed config.
+// After is nil for a deleted config in a validate-only request, since the
+// default it reverts to is only known once applied.
+type TopicConfigChange struct {
+	Name      string  `json:"name"`
+	Operation string  `json:"operation"`
+	Before    *string `json:"before"`
+	After     *string `json:"after"`
+}
+
+// TopicConfigUpdateResult reports the outcome of a topic config update.
+type TopicConfigUpdateResult struct {
+	Topic        string              `json:"topic"`
+	ValidateOnly bool                `json:"validateOnly"`
+	Changes      []TopicConfigChange `json:"changes"`
+}
+
+// UpdateTopicConfigs applies an incremental config update to a topic with
+// IncrementalAlterConfigs, which requires Kafka 2.3 or later. With
+// ValidateOnly the broker checks the update without applying it.
+func (s *TopicService) UpdateTopicConfigs(ctx context.Context, clusterName, topicName string, update TopicConfigUpdate) (*TopicConfigUpdateResult, error) {
+	admin, err := s.kafkaService.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if !version.IsAtLeast(sarama.V2_3_0_0) {
+		return nil, fmt.Errorf("editing topic configs requires Kafka 2.3.0 or later, cluster %s runs %s", clusterName, version)
+	}
+
+	resource := sarama.ConfigResource{Type: sarama.TopicResource, Name: topicName}
+	before, err := describeConfigValues(admin, resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe configs of topic %s: %w", topicName, err)
+	}
+
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(update.Set)+len(update.Delete))
+	for name, value := range update.Set {
+		entries[name] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationSet,
+			Value:     &value,
+		}
+	}
+	for _, name := range update.Delete {
+		entries[name] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationDelete,
+		}
+	}
+
+	if err := admin.IncrementalAlterConfig(sarama.TopicResource, topicName, entries, update.ValidateOnly); err != nil {
+		return nil, fmt.Errorf("failed to alter configs of topic %s: %w", topicName, err)
+	}
+
+	after := make(map[string]string, len(before))
+	if update.ValidateOnly {
+		for name, value := range before {
+			after[name] = value
+		}
+		for name, value := range update.Set {
+			after[name] = value
+		}
+		for _, name := range update.Delete {
+			delete(after, name)
+		}
+	} else if after, err = describeConfigValues(admin, resource); err != nil {
+		return nil, fmt.Errorf("configs of topic %s were updated but could not be read back: %w", topicName, err)
+	}
+
+	result := &TopicConfigUpdateResult{
+		Topic:        topicName,
+		ValidateOnly: update.ValidateOnly,
+		Changes:      make([]TopicConfigChange, 0, len(entries)),
+	}
+	for name, entry := range entries {
+		change := TopicConfigChange{Name: name, Operation: "set"}
+		if entry.Operation == sarama.IncrementalAlterConfigsOperationDelete {
+			change.Operation = "delete"
+		}
+		if value, ok := before[name]; ok {
+			change.Before = &value
+		}
+		if value, ok := after[name]; ok {
+			change.After = &value
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	sort.Slice(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Name < result.Changes[j].Name
+	})
+	return result, nil
+}
+
+// describeConfigValues returns the current config values of a resource by name.
+func describeConfigValues(admin sarama.ClusterAdmin, resource sarama.ConfigResource) (map[string]string, error) {
+	entries, err := admin.DescribeConfig(resource)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		values[entry.Name] = entry.Value
+	}
+	return values, nil
+}
+
+// APIConfigEntry is a config entry with its origin, as shown in topic details.
+type APIConfigEntry struct {
+	Name          string             `json:"name"`
+	Value         string             `json:"value"`
+	Source        string             `json:"source"`
+	IsDefault     bool               `json:"isDefault"`
+	ReadOnly      bool               `json:"readOnly"`
+	Sensitive     bool               `json:"sensitive"`
+	Synonyms      []APIConfigSynonym `json:"synonyms"`
+	Documentation string             `json:"documentation,omitempty"`
+}
+
+// APIConfigSynonym is a lower-precedence definition of a config, such as the
+// broker setting a topic override shadows.
+type APIConfigSynonym struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// configSourceName returns the Kafka name of a config source.
+func configSourceName(source sarama.ConfigSource) string {
+	switch source {
+	case sarama.SourceTopic:
+		return "DYNAMIC_TOPIC_CONFIG"
+	case sarama.SourceDynamicBroker:
+		return "DYNAMIC_BROKER_CONFIG"
+	case sarama.SourceDynamicDefaultBroker:
+		return "DYNAMIC_DEFAULT_BROKER_CONFIG"
+	case sarama.SourceStaticBroker:
+		return "STATIC_BROKER_CONFIG"
+	case sarama.SourceDefault:
+		return "DEFAULT_CONFIG"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// describeTopicConfigEntries returns the config entries of a topic with their
+// synonyms, sorted by name. sarama's DescribeConfig does not request
+// synonyms, so the request is sent to the controller directly.
+func describeTopicConfigEntries(client sarama.Client, version sarama.KafkaVersion, topicName string) ([]APIConfigEntry, error) {
+	request := &sarama.DescribeConfigsRequest{
+		Resources: []*sarama.ConfigResource{{Type: sarama.TopicResource, Name: topicName}},
+	}
+	if version.IsAtLeast(sarama.V1_1_0_0) {
+		request.Version = 1
+		request.IncludeSynonyms = true
+	}
+	if version.IsAtLeast(sarama.V2_0_0_0) {
+		request.Version = 2
+	}
+
+	controller, err := client.Controller()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := controller.DescribeConfigs(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []APIConfigEntry
+	for _, resource := range resp.Resources {
+		if resource.Name != topicName {
+			continue
+		}
+		if resource.ErrorCode != 0 {
+			return nil, &sarama.DescribeConfigError{Err: sarama.KError(resource.ErrorCode), ErrMsg: resource.ErrorMsg}
+		}
+		for _, config := range resource.Configs {
+			entry := APIConfigEntry{
+				Name:          config.Name,
+				Value:         config.Value,
+				Source:        configSourceName(config.Source),
+				IsDefault:     config.Default,
+				ReadOnly:      config.ReadOnly,
+				Sensitive:     config.Sensitive,
+				Synonyms:      make([]APIConfigSynonym, 0, len(config.Synonyms)),
+				Documentation: topicConfigSpecs[config.Name].doc,
+			}
+			if config.Sensitive {
+				entry.Value = ""
+			}
+			for _, synonym := range config.Synonyms {
+				value := synonym.ConfigValue
+				if config.Sensitive {
+					value = ""
+				}
+				entry.Synonyms = append(entry.Synonyms, APIConfigSynonym{
+					Name:   synonym.ConfigName,
+					Value:  value,
+					Source: configSourceName(synonym.Source),
+				})
+			}
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
@@ -0,0 +1,317 @@
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// Topic list sort keys.
+const (
+	TopicSortName       = "name"
+	TopicSortPartitions = "partitions"
+	TopicSortSize       = "size"
+)
+
+// Topic list page sizes.
+const (
+	DefaultTopicPageSize = 50
+	MaxTopicPageSize     = 500
+)
+
+// internalTopics are hidden by TopicListQuery.HideInternal in addition to
+// the topics the broker itself flags as internal.
+var internalTopics = map[string]bool{
+	"__consumer_offsets":  true,
+	"__transaction_state": true,
+	"_schemas":            true,
+}
+
+// TopicListQuery filters, sorts and paginates the topic list. Page is
+// 1-based and ignored when Cursor is set.
+type TopicListQuery struct {
+	Search       string
+	Regex        bool
+	SortBy       string
+	Descending   bool
+	Page         int
+	PageSize     int
+	Cursor       string
+	HideInternal bool
+}
+
+// Validate checks the query and fills in defaults.
+func (q *TopicListQuery) Validate() error {
+	if q.SortBy == "" {
+		q.SortBy = TopicSortName
+	}
+	switch q.SortBy {
+	case TopicSortName, TopicSortPartitions, TopicSortSize:
+	default:
+		return fmt.Errorf("%w: sort must be one of %s, %s or %s", ErrInvalidRequest, TopicSortName, TopicSortPartitions, TopicSortSize)
+	}
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.Page < 1 {
+		return fmt.Errorf("%w: page must be at least 1", ErrInvalidRequest)
+	}
+	if q.PageSize == 0 {
+		q.PageSize = DefaultTopicPageSize
+	}
+	if q.PageSize < 1 || q.PageSize > MaxTopicPageSize {
+		return fmt.Errorf("%w: pageSize must be between 1 and %d", ErrInvalidRequest, MaxTopicPageSize)
+	}
+	if q.Regex {
+		if _, err := regexp.Compile(q.Search); err != nil {
+			return fmt.Errorf("%w: invalid search pattern: %v", ErrInvalidRequest, err)
+		}
+	}
+	return nil
+}
+
+// TopicPage is one page of the topic list. Total counts every topic in the
+// cluster and Matched those left after search and internal-topic filtering.
+// NextCursor is empty on the last page.
+type TopicPage struct {
+	Topics     []APITopicSummary `json:"topics"`
+	Total      int               `json:"total"`
+	Matched    int               `json:"matched"`
+	Page       int               `json:"page,omitempty"`
+	PageSize   int               `json:"pageSize"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	Warnings   []string          `json:"warnings"`
+}
+
+// topicCursor is the position after the last topic of a page. It records
+// the sort so a cursor cannot be replayed against a different order.
+type topicCursor struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	Name       string `json:"n"`
+	Value      int64  `json:"v,omitempty"`
+}
+
+func encodeTopicCursor(cursor topicCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeTopicCursor(encoded string) (topicCursor, error) {
+	var cursor topicCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil {
+		return cursor, fmt.Errorf("%w: malformed cursor", ErrInvalidRequest)
+	}
+	return cursor, nil
+}
+
+// topicEntry is a topic with the metadata needed to filter, sort and size it.
+type topicEntry struct {
+	summary  APITopicSummary
+	leaders  map[int32]int32
+	replicas map[int32][]int32
+}
+
+// sortValue returns the numeric sort key of the entry, or 0 for name sorting.
+func (e *topicEntry) sortValue(sortBy string) int64 {
+	switch sortBy {
+	case TopicSortPartitions:
+		return int64(e.summary.PartitionCount)
+	case TopicSortSize:
+		return e.summary.Size
+	}
+	return 0
+}
+
+// before reports whether a topic with the given sort value and name comes
+// before b in the query order. Names break ties so the order is total, which
+// cursor pagination relies on.
+func (q *TopicListQuery) before(value int64, name string, b *topicEntry) bool {
+	other := b.sortValue(q.SortBy)
+	if value == other && name == b.summary.Name {
+		return false
+	}
+	less := value < other || (value == other && name < b.summary.Name)
+	if q.Descending {
+		return !less
+	}
+	return less
+}
+
+// matcher returns the name filter of the query.
+func (q *TopicListQuery) matcher() func(string) bool {
+	if q.Search == "" {
+		return func(string) bool { return true }
+	}
+	if q.Regex {
+		pattern := regexp.MustCompile(q.Search)
+		return pattern.MatchString
+	}
+	search := strings.ToLower(q.Search)
+	return func(name string) bool {
+		return strings.Contains(strings.ToLower(name), search)
+	}
+}
+
+// GetTopics returns one page of the topic list of a cluster. Topics come
+// from a single metadata request; sizes are only collected for the returned
+// page, or for every matching topic when sorting by size.
+func (s *TopicService) GetTopics(ctx context.Context, clusterName string, query TopicListQuery) (*TopicPage, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	var cursor *topicCursor
+	if query.Cursor != "" {
+		decoded, err := decodeTopicCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.SortBy != query.SortBy || decoded.Descending != query.Descending {
+			return nil, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidRequest)
+		}
+		cursor = &decoded
+	}
+
+	client, err := s.kafkaService.GetSaramaClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.kafkaService.KafkaVersion(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	controller, err := client.Controller()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics for cluster %s: %w", clusterName, err)
+	}
+	metadata, err := controller.GetMetadata(sarama.NewMetadataRequest(version, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics for cluster %s: %w", clusterName, err)
+	}
+
+	match := query.matcher()
+	page := &TopicPage{
+		Topics:   []APITopicSummary{},
+		Total:    len(metadata.Topics),
+		PageSize: query.PageSize,
+		Warnings: []string{},
+	}
+	var entries []*topicEntry
+	for _, topic := range metadata.Topics {
+		internal := topic.IsInternal || internalTopics[topic.Name]
+		if (query.HideInternal && internal) || !match(topic.Name) {
+			continue
+		}
+		entry := &topicEntry{
+			summary: APITopicSummary{
+				Name:           topic.Name,
+				PartitionCount: len(topic.Partitions),
+				Internal:       internal,
+			},
+			leaders:  make(map[int32]int32, len(topic.Partitions)),
+			replicas: make(map[int32][]int32, len(topic.Partitions)),
+		}
+		for _, p := range topic.Partitions {
+			entry.leaders[p.ID] = p.Leader
+			entry.replicas[p.ID] = p.Replicas
+		}
+		if len(topic.Partitions) > 0 {
+			entry.summary.ReplicationFactor = len(topic.Partitions[0].Replicas) // Assuming RF is consistent
+		}
+		entries = append(entries, entry)
+	}
+	page.Matched = len(entries)
+
+	if query.SortBy == TopicSortSize {
+		if err := fillTopicSizes(client, version, entries); err != nil {
+			return nil, fmt.Errorf("failed to get topic sizes for sorting: %w", err)
+		}
+	}
+	query.sort(entries)
+
+	start, end := query.bounds(entries, cursor)
+	if cursor == nil {
+		page.Page = query.Page
+	}
+	entries = entries[start:end]
+
+	if query.SortBy != TopicSortSize && len(entries) > 0 {
+		if err := fillTopicSizes(client, version, entries); err != nil {
+			page.Warnings = append(page.Warnings, "topic sizes are unavailable: "+err.Error())
+		}
+	}
+	for _, entry := range entries {
+		page.Topics = append(page.Topics, entry.summary)
+	}
+	if end < page.Matched && len(entries) > 0 {
+		page.NextCursor = query.cursorAfter(entries[len(entries)-1])
+	}
+	return page, nil
+}
+
+// sort orders entries by the query.
+func (q *TopicListQuery) sort(entries []*topicEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return q.before(entries[i].sortValue(q.SortBy), entries[i].summary.Name, entries[j])
+	})
+}
+
+// bounds returns the slice of the sorted entries that makes up the page:
+// the entries after the cursor, or the query's page when there is none.
+func (q *TopicListQuery) bounds(entries []*topicEntry, cursor *topicCursor) (start, end int) {
+	start = (q.Page - 1) * q.PageSize
+	if cursor != nil {
+		start = sort.Search(len(entries), func(i int) bool {
+			return q.before(cursor.Value, cursor.Name, entries[i])
+		})
+	}
+	if start > len(entries) {
+		start = len(entries)
+	}
+	end = start + q.PageSize
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return start, end
+}
+
+// cursorAfter returns the cursor of the page that follows entry.
+func (q *TopicListQuery) cursorAfter(entry *topicEntry) string {
+	return encodeTopicCursor(topicCursor{
+		SortBy:     q.SortBy,
+		Descending: q.Descending,
+		Name:       entry.summary.Name,
+		Value:      entry.sortValue(q.SortBy),
+	})
+}
+
+// fillTopicSizes sets the size of each entry to the total of its leader
+// replicas.
+func fillTopicSizes(client sarama.Client, version sarama.KafkaVersion, entries []*topicEntry) error {
+	replicas := make(map[string]map[int32][]int32, len(entries))
+	for _, entry := range entries {
+		replicas[entry.summary.Name] = entry.replicas
+	}
+	sizes, err := logDirSizes(client, version, replicas)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.summary.Size = 0
+		for partition, leader := range entry.leaders {
+			entry.summary.Size += leaderSize(leader, sizes[entry.summary.Name][partition])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package kafka
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTopicListQueryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   TopicListQuery
+		want    TopicListQuery
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			query: TopicListQuery{},
+			want:  TopicListQuery{SortBy: TopicSortName, Page: 1, PageSize: DefaultTopicPageSize},
+		},
+		{
+			name:  "explicit values are kept",
+			query: TopicListQuery{SortBy: TopicSortSize, Descending: true, Page: 3, PageSize: MaxTopicPageSize},
+			want:  TopicListQuery{SortBy: TopicSortSize, Descending: true, Page: 3, PageSize: MaxTopicPageSize},
+		},
+		{
+			name:  "valid regex",
+			query: TopicListQuery{Search: "^orders-[0-9]+$", Regex: true},
+			want:  TopicListQuery{Search: "^orders-[0-9]+$", Regex: true, SortBy: TopicSortName, Page: 1, PageSize: DefaultTopicPageSize},
+		},
+		{
+			name:  "invalid pattern is a plain substring without regex",
+			query: TopicListQuery{Search: "orders["},
+			want:  TopicListQuery{Search: "orders[", SortBy: TopicSortName, Page: 1, PageSize: DefaultTopicPageSize},
+		},
+		{name: "unknown sort", query: TopicListQuery{SortBy: "replicas"}, wantErr: true},
+		{name: "negative page", query: TopicListQuery{Page: -1}, wantErr: true},
+		{name: "negative page size", query: TopicListQuery{PageSize: -1}, wantErr: true},
+		{name: "page size too large", query: TopicListQuery{PageSize: MaxTopicPageSize + 1}, wantErr: true},
+		{name: "invalid regex", query: TopicListQuery{Search: "orders[", Regex: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			err := query.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRequest) {
+					t.Fatalf("Validate() error = %v, want ErrInvalidRequest", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if query != tt.want {
+				t.Errorf("Validate() query = %+v, want %+v", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopicListPagination(t *testing.T) {
+	// Topics as name and partition count; several share a partition count
+	// so the name tie-break decides their order.
+	topics := []struct {
+		name       string
+		partitions int
+	}{
+		{"d", 1}, {"a", 0}, {"e", 0}, {"b", 1}, {"c", 0}, {"f", 3},
+	}
+	newEntries := func() []*topicEntry {
+		entries := make([]*topicEntry, 0, len(topics))
+		for _, topic := range topics {
+			entries = append(entries, &topicEntry{summary: APITopicSummary{Name: topic.name, PartitionCount: topic.partitions}})
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name       string
+		sortBy     string
+		descending bool
+		pageSize   int
+		want       []string
+	}{
+		{name: "name ascending", sortBy: TopicSortName, pageSize: 2, want: []string{"a", "b", "c", "d", "e", "f"}},
+		{name: "name descending", sortBy: TopicSortName, descending: true, pageSize: 4, want: []string{"f", "e", "d", "c", "b", "a"}},
+		{name: "equal values ascending", sortBy: TopicSortPartitions, pageSize: 2, want: []string{"a", "c", "e", "b", "d", "f"}},
+		{name: "equal values descending", sortBy: TopicSortPartitions, descending: true, pageSize: 2, want: []string{"f", "d", "b", "e", "c", "a"}},
+		{name: "page splits a tie", sortBy: TopicSortPartitions, pageSize: 1, want: []string{"a", "c", "e", "b", "d", "f"}},
+		{name: "single page", sortBy: TopicSortPartitions, pageSize: 10, want: []string{"a", "c", "e", "b", "d", "f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := TopicListQuery{SortBy: tt.sortBy, Descending: tt.descending, PageSize: tt.pageSize}
+			if err := query.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			entries := newEntries()
+			query.sort(entries)
+
+			// Follow the cursors to the last page, which has none.
+			var got []string
+			var cursor *topicCursor
+			for pages := 0; ; pages++ {
+				if pages > len(entries) {
+					t.Fatal("cursors do not reach the last page")
+				}
+				start, end := query.bounds(entries, cursor)
+				for _, entry := range entries[start:end] {
+					got = append(got, entry.summary.Name)
+				}
+				if end == len(entries) {
+					break
+				}
+				decoded, err := decodeTopicCursor(query.cursorAfter(entries[end-1]))
+				if err != nil {
+					t.Fatal(err)
+				}
+				cursor = &decoded
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cursor pages = %v, want %v", got, tt.want)
+			}
+
+			// Numbered pages yield the same order.
+			got = nil
+			for query.Page = 1; ; query.Page++ {
+				start, end := query.bounds(entries, nil)
+				if start == end {
+					break
+				}
+				for _, entry := range entries[start:end] {
+					got = append(got, entry.summary.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("numbered pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopicListCursorAfterRemovedTopic(t *testing.T) {
+	// A cursor still resumes after its topic when that topic has since been
+	// deleted.
+	query := TopicListQuery{SortBy: TopicSortPartitions, PageSize: 2}
+	if err := query.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	entries := []*topicEntry{
+		{summary: APITopicSummary{Name: "a", PartitionCount: 1}},
+		{summary: APITopicSummary{Name: "c", PartitionCount: 1}},
+		{summary: APITopicSummary{Name: "d", PartitionCount: 2}},
+	}
+	cursor := &topicCursor{SortBy: TopicSortPartitions, Name: "b", Value: 1}
+	start, end := query.bounds(entries, cursor)
+	if start != 1 || end != 3 {
+		t.Errorf("bounds() = %d, %d, want 1, 3", start, end)
+	}
+
+	cursor = &topicCursor{SortBy: TopicSortPartitions, Name: "z", Value: 9}
+	if start, end := query.bounds(entries, cursor); start != 3 || end != 3 {
+		t.Errorf("bounds() past the end = %d, %d, want 3, 3", start, end)
+	}
+}
@@ -0,0 +1,193 @@
+package kafka
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// APIReplicaSize is the on-disk size of one partition replica.
+type APIReplicaSize struct {
+	BrokerID int32  `json:"brokerId"`
+	LogDir   string `json:"logDir"`
+	Size     int64  `json:"size"`
+	// OffsetLag is how far the replica's log end offset is behind the high
+	// watermark, or behind the current log for a future replica.
+	OffsetLag int64 `json:"offsetLag"`
+	// IsFuture is set for a replica being moved to another log directory.
+	IsFuture bool `json:"isFuture"`
+}
+
+// watermarks holds the first and next offsets of a partition.
+type watermarks struct {
+	low, high int64
+}
+
+// partitionWatermarks fetches the low and high watermarks of each partition
+// concurrently.
+func partitionWatermarks(client sarama.Client, topicName string, partitions []int32) (map[int32]watermarks, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	result := make(map[int32]watermarks, len(partitions))
+	var firstErr error
+
+	for _, partition := range partitions {
+		wg.Add(1)
+		go func(partitionID int32) {
+			defer wg.Done()
+
+			low, err := client.GetOffset(topicName, partitionID, sarama.OffsetOldest)
+			if err == nil {
+				var high int64
+				high, err = client.GetOffset(topicName, partitionID, sarama.OffsetNewest)
+				if err == nil {
+					mu.Lock()
+					result[partitionID] = watermarks{low: low, high: high}
+					mu.Unlock()
+					return
+				}
+			}
+
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to get offsets for partition %d: %w", partitionID, err)
+			}
+			mu.Unlock()
+		}(partition)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// replicaLogSizes returns the log directory sizes of a topic's partition
+// replicas, keyed by partition and ordered by broker ID.
+func replicaLogSizes(client sarama.Client, version sarama.KafkaVersion, topicName string, replicas map[int32][]int32) (map[int32][]APIReplicaSize, error) {
+	sizes, err := logDirSizes(client, version, map[string]map[int32][]int32{topicName: replicas})
+	if err != nil {
+		return nil, err
+	}
+	return sizes[topicName], nil
+}
+
+// logDirSizes asks each replica broker for the log directory sizes of the
+// given partitions, keyed by topic and partition. replicas maps each topic's
+// partitions to their replica broker IDs. DescribeLogDirs needs Kafka 1.0 or
+// newer.
+func logDirSizes(client sarama.Client, version sarama.KafkaVersion, replicas map[string]map[int32][]int32) (map[string]map[int32][]APIReplicaSize, error) {
+	if !version.IsAtLeast(sarama.V1_0_0_0) {
+		return nil, fmt.Errorf("describing log dirs requires Kafka 1.0 or newer, cluster is %s", version)
+	}
+
+	// Group the partitions by the brokers hosting them so each broker is
+	// asked only about its own replicas.
+	byBroker := make(map[int32]map[string][]int32)
+	for topic, partitions := range replicas {
+		for partition, brokers := range partitions {
+			for _, broker := range brokers {
+				if byBroker[broker] == nil {
+					byBroker[broker] = make(map[string][]int32)
+				}
+				byBroker[broker][topic] = append(byBroker[broker][topic], partition)
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	result := make(map[string]map[int32][]APIReplicaSize, len(replicas))
+	var firstErr error
+
+	for brokerID, topics := range byBroker {
+		request := &sarama.DescribeLogDirsRequest{
+			DescribeTopics: make([]sarama.DescribeLogDirsRequestTopic, 0, len(topics)),
+		}
+		for topic, partitions := range topics {
+			request.DescribeTopics = append(request.DescribeTopics, sarama.DescribeLogDirsRequestTopic{Topic: topic, PartitionIDs: partitions})
+		}
+		if version.IsAtLeast(sarama.V2_0_0_0) {
+			request.Version = 1
+		}
+
+		wg.Add(1)
+		go func(brokerID int32, request *sarama.DescribeLogDirsRequest) {
+			defer wg.Done()
+
+			dirs, err := describeBrokerLogDirs(client, brokerID, request)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to describe log dirs on broker %d: %w", brokerID, err)
+				}
+				return
+			}
+			for _, dir := range dirs {
+				if dir.ErrorCode != sarama.ErrNoError {
+					// A failed log directory holds no readable replicas;
+					// the others on the broker are still reported.
+					continue
+				}
+				for _, topic := range dir.Topics {
+					if _, requested := replicas[topic.Topic]; !requested {
+						continue
+					}
+					if result[topic.Topic] == nil {
+						result[topic.Topic] = make(map[int32][]APIReplicaSize)
+					}
+					for _, p := range topic.Partitions {
+						result[topic.Topic][p.PartitionID] = append(result[topic.Topic][p.PartitionID], APIReplicaSize{
+							BrokerID:  brokerID,
+							LogDir:    dir.Path,
+							Size:      p.Size,
+							OffsetLag: p.OffsetLag,
+							IsFuture:  p.IsTemporary,
+						})
+					}
+				}
+			}
+		}(brokerID, request)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	for _, partitions := range result {
+		for _, sizes := range partitions {
+			sort.Slice(sizes, func(i, j int) bool {
+				return sizes[i].BrokerID < sizes[j].BrokerID
+			})
+		}
+	}
+	return result, nil
+}
+
+// describeBrokerLogDirs sends a DescribeLogDirs request to a single broker.
+func describeBrokerLogDirs(client sarama.Client, brokerID int32, request *sarama.DescribeLogDirsRequest) ([]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	broker, err := client.Broker(brokerID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := broker.DescribeLogDirs(request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LogDirs, nil
+}
+
+// leaderSize returns the on-disk size of a partition's leader replica.
+func leaderSize(leader int32, sizes []APIReplicaSize) int64 {
+	for _, replica := range sizes {
+		if replica.BrokerID == leader && !replica.IsFuture {
+			return replica.Size
+		}
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+// Kafka protocol API keys used to infer the broker version.
+const (
+	apiKeyFetch                       = 1
+	apiKeyAlterPartitionReassignments = 45
+	apiKeyDescribeCluster             = 60
+	apiKeyDescribeTransactions        = 65
+)
+
+// versionProbes maps the API support introduced by a Kafka release to that
+// release, newest first. The first probe the broker satisfies wins.
+var versionProbes = []struct {
+	version    sarama.KafkaVersion
+	apiKey     int16
+	maxVersion int16
+}{
+	{sarama.V3_7_0_0, apiKeyFetch, 16},
+	{sarama.V3_5_0_0, apiKeyFetch, 15},
+	{sarama.V3_1_0_0, apiKeyFetch, 13},
+	{sarama.V3_0_0_0, apiKeyDescribeTransactions, 0},
+	{sarama.V2_8_0_0, apiKeyDescribeCluster, 0},
+	{sarama.V2_7_0_0, apiKeyFetch, 12},
+	{sarama.V2_4_0_0, apiKeyAlterPartitionReassignments, 0},
+	{sarama.V2_3_0_0, apiKeyFetch, 11},
+	{sarama.V2_1_0_0, apiKeyFetch, 10},
+	{sarama.V2_0_0_0, apiKeyFetch, 8},
+	{sarama.V1_1_0_0, apiKeyFetch, 7},
+	{sarama.V1_0_0_0, apiKeyFetch, 6},
+	{sarama.V0_11_0_0, apiKeyFetch, 4},
+	{sarama.V0_10_1_0, apiKeyFetch, 3},
+}
+
+// resolveVersion returns the configured version override if set, otherwise
+// the version negotiated with the cluster.
+func resolveVersion(override string, brokers []string, sec *security) (sarama.KafkaVersion, error) {
+	if override != "" {
+		version, err := sarama.ParseKafkaVersion(override)
+		if err != nil {
+			return sarama.KafkaVersion{}, fmt.Errorf("invalid Kafka version %q: %w", override, err)
+		}
+		return version, nil
+	}
+	return detectVersion(brokers, sec)
+}
+
+// detectVersion asks the first reachable bootstrap broker which API versions
+// it supports and returns the highest Kafka version supported by both the
+// broker and sarama.
+func detectVersion(brokers []string, sec *security) (sarama.KafkaVersion, error) {
+	// ApiVersions v0 is understood by every broker since 0.10.0.
+	cfg := newSaramaConfig(sec, sarama.V1_0_0_0)
+	cfg.ApiVersionsRequest = false
+
+	lastErr := errors.New("no brokers configured")
+	for _, addr := range brokers {
+		broker := sarama.NewBroker(addr)
+		if err := broker.Open(cfg); err != nil {
+			lastErr = err
+			continue
+		}
+
+		resp, err := broker.ApiVersions(&sarama.ApiVersionsRequest{})
+		broker.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if kerr := sarama.KError(resp.ErrorCode); kerr != sarama.ErrNoError {
+			lastErr = kerr
+			continue
+		}
+
+		return versionFromAPIKeys(resp.ApiKeys), nil
+	}
+	return sarama.KafkaVersion{}, fmt.Errorf("failed to negotiate Kafka version: %w", lastErr)
+}
+
+// versionFromAPIKeys infers the Kafka release from an ApiVersions response.
+func versionFromAPIKeys(keys []sarama.ApiVersionsResponseKey) sarama.KafkaVersion {
+	supported := make(map[int16]int16, len(keys))
+	for _, key := range keys {
+		supported[key.ApiKey] = key.MaxVersion
+	}
+
+	for _, probe := range versionProbes {
+		if maxVersion, ok := supported[probe.apiKey]; ok && maxVersion >= probe.maxVersion {
+			if sarama.MaxVersion.IsAtLeast(probe.version) {
+				return probe.version
+			}
+			return sarama.MaxVersion
+		}
+	}
+	return sarama.V0_10_0_0
+}
@@ -0,0 +1,67 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestVersionFromAPIKeys(t *testing.T) {
+	// Key sets are trimmed from the ApiVersions responses of each release
+	// to the keys the probes look at plus a few that every broker reports.
+	keys := func(maxVersions map[int16]int16) []sarama.ApiVersionsResponseKey {
+		var result []sarama.ApiVersionsResponseKey
+		for key, maxVersion := range maxVersions {
+			result = append(result, sarama.ApiVersionsResponseKey{ApiKey: key, MaxVersion: maxVersion})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		keys []sarama.ApiVersionsResponseKey
+		want sarama.KafkaVersion
+	}{
+		{
+			name: "no keys",
+			want: sarama.V0_10_0_0,
+		},
+		{
+			name: "0.10.2",
+			keys: keys(map[int16]int16{0: 2, 1: 3, 3: 2, 18: 0}),
+			want: sarama.V0_10_1_0,
+		},
+		{
+			name: "0.11",
+			keys: keys(map[int16]int16{0: 3, 1: 5, 3: 4, 18: 1}),
+			want: sarama.V0_11_0_0,
+		},
+		{
+			name: "2.4",
+			keys: keys(map[int16]int16{0: 8, 1: 11, 3: 9, 18: 3, 43: 2, 45: 0, 46: 0}),
+			want: sarama.V2_4_0_0,
+		},
+		{
+			name: "2.8",
+			keys: keys(map[int16]int16{0: 9, 1: 12, 3: 11, 18: 3, 45: 0, 60: 0}),
+			want: sarama.V2_8_0_0,
+		},
+		{
+			name: "3.5",
+			keys: keys(map[int16]int16{0: 9, 1: 15, 3: 12, 18: 3, 45: 0, 60: 0, 65: 0}),
+			want: sarama.V3_5_0_0,
+		},
+		{
+			name: "3.7",
+			keys: keys(map[int16]int16{0: 10, 1: 16, 3: 12, 18: 3, 45: 0, 60: 0, 65: 0}),
+			want: sarama.V3_7_0_0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionFromAPIKeys(tt.keys); got != tt.want {
+				t.Errorf("versionFromAPIKeys() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,38 @@
 package models
 
+import (
+	"time"
+
+	"github.com/nikhilgoenkatech/kafka-ui/internal/config"
+)
+
 type Cluster struct {
-	Name             string `json:"name"`
-	BootstrapServers string `json:"bootstrapServers"`
-	Zookeeper        string `json:"zookeeper"`
+	Name             string        `json:"name"`
+	BootstrapServers string        `json:"bootstrapServers"`
+	Zookeeper        string        `json:"zookeeper"`
+	KafkaVersion     string        `json:"kafkaVersion"`
+	ClusterID        string        `json:"clusterId"`
+	ControllerID     int32         `json:"controllerId"`
+	BrokerCount      int           `json:"brokerCount"`
+	TopicCount       int           `json:"topicCount"`
+	Tags             []string      `json:"tags"`
+	Static           bool          `json:"static"`
+	ReadOnly         bool          `json:"readOnly"`
+	Status           ClusterStatus `json:"status"`
+	// Config is the cluster definition with secrets removed. It is only
+	// set on single-cluster responses, so a client can edit and send it back.
+	Config *config.ClusterConfig `json:"config,omitempty"`
+}
+
+// ClusterStatus describes the connection health of a cluster as last seen
+// by the health monitor.
+type ClusterStatus struct {
+	State           string     `json:"state"`
+	StateSince      time.Time  `json:"stateSince"`
+	LastCheckedAt   time.Time  `json:"lastCheckedAt"`
+	LastConnectedAt *time.Time `json:"lastConnectedAt,omitempty"`
+	LastError       string     `json:"lastError,omitempty"`
+	LastErrorAt     *time.Time `json:"lastErrorAt,omitempty"`
 }
 
 type Topic struct {
@@ -90,10 +90,34 @@ func AuthMiddleware(config JWTConfig) gin.HandlerFunc {
 	}
 }
 
-// CORSMiddleware creates CORS middleware
-func CORSMiddleware() gin.HandlerFunc {
+// CORSMiddleware creates CORS middleware. Requests from origins not in
+// allowedOrigins get no CORS headers; an empty list or "*" allows every origin.
+func CORSMiddleware(allowedOrigins []string) gin.HandlerFunc {
+	allowAll := len(allowedOrigins) == 0
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == constants.HeaderValueAllowOriginAll {
+			allowAll = true
+		}
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+
 	return func(c *gin.Context) {
-		c.Header(constants.HeaderAccessControlAllowOrigin, constants.HeaderValueAllowOriginAll)
+		if allowAll {
+			c.Header(constants.HeaderAccessControlAllowOrigin, constants.HeaderValueAllowOriginAll)
+		} else {
+			c.Header(constants.HeaderVary, constants.HeaderOrigin)
+			origin := c.GetHeader(constants.HeaderOrigin)
+			if !allowed[origin] {
+				if c.Request.Method == "OPTIONS" {
+					c.AbortWithStatus(http.StatusForbidden)
+					return
+				}
+				c.Next()
+				return
+			}
+			c.Header(constants.HeaderAccessControlAllowOrigin, origin)
+		}
 		c.Header(constants.HeaderAccessControlAllowCredentials, constants.HeaderValueAllowCredentialsTrue)
 		c.Header(constants.HeaderAccessControlAllowHeaders, constants.HeaderValueAllowHeaders)
 		c.Header(constants.HeaderAccessControlAllowMethods, constants.HeaderValueAllowMethods)
@@ -30,7 +30,7 @@ export const ClusterProvider = ({ children }) => {
     setLoading(true);
     try {
       const response = await api.cluster.getClusters();
-      const data = response.data || [];
+      const data = (response.data || []).map(cluster => cluster.name);
       setClusters(data);
       if (data && data.length > 0 && !selectedCluster) {
         setSelectedCluster(data[0]);
@@ -31,12 +31,12 @@ export default function Overview() {
             try {
                 const [brokersRes, topicsRes, consumersRes] = await Promise.all([
                     api.get(`/clusters/${clusterName}/brokers`),
-                    api.get(`/clusters/${clusterName}/topics`),
+                    api.get(`/clusters/${clusterName}/topics`, { params: { pageSize: 1 } }),
                     api.get(`/clusters/${clusterName}/consumer-groups`)
                 ]);
                 setStats({
                     brokers: brokersRes.data?.length || 0,
-                    topics: topicsRes.data?.length || 0,
+                    topics: topicsRes.data?.total || 0,
                     consumerGroups: consumersRes.data?.length || 0,
                 });
             } catch (error) {
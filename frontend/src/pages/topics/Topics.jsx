@@ -73,6 +73,7 @@ const tableStyles = {
 
 function Topics() {
   const [topics, setTopics] = useState([]);
+  const [matched, setMatched] = useState(0);
   const [loading, setLoading] = useState(true);
   const { enqueueSnackbar } = useSnackbar();
   const navigate = useNavigate();
@@ -86,12 +87,16 @@ function Topics() {
     if (!clusterName) {
       setLoading(false);
       setTopics([]);
+      setMatched(0);
       return;
     }
     setLoading(true);
     try {
-      const response = await api.get(`/clusters/${clusterName}/topics`);
-      setTopics(response.data || []);
+      const response = await api.get(`/clusters/${clusterName}/topics`, {
+        params: { search, page, pageSize: PAGE_SIZE },
+      });
+      setTopics(response.data?.topics || []);
+      setMatched(response.data?.matched || 0);
     } catch (err) {
       enqueueSnackbar(err.message, { variant: 'error' });
     } finally {
@@ -102,7 +107,7 @@ function Topics() {
   useEffect(() => {
     fetchTopics();
     // eslint-disable-next-line
-  }, [clusterName]);
+  }, [clusterName, search, page]);
 
   const handleDeleteClick = (topicName) => {
     setTopicToDelete(topicName);
@@ -123,11 +128,8 @@ function Topics() {
     }
   };
 
-  const filteredTopics = (topics || []).filter(topic =>
-    topic.name.toLowerCase().includes(search.toLowerCase())
-  );
-  const pageCount = Math.ceil(filteredTopics.length / PAGE_SIZE);
-  const paginatedTopics = filteredTopics.slice((page - 1) * PAGE_SIZE, page * PAGE_SIZE);
+  const pageCount = Math.ceil(matched / PAGE_SIZE);
+  const paginatedTopics = topics || [];
 
   useEffect(() => {
     setPage(1); // Reset to first page on search
@@ -229,7 +231,7 @@ function Topics() {
               </TableContainer>
             )}
           </CardContent>
-          {filteredTopics.length > 0 && (
+          {matched > 0 && (
             <Box sx={{ borderTop: '1px solid', borderColor: 'divider', p: 2 }}>
               <Pagination
                 count={pageCount}